@@ -10,6 +10,7 @@ type Context struct {
 	variables  map[string]Valuer
 	functions  map[string]map[int]Function
 	converters map[reflect.Type]Converter
+	options    map[string]interface{}
 	next       *Context
 }
 
@@ -70,11 +71,32 @@ func (c *Context) DefineConverter(t reflect.Type, co Converter) {
 	c.converters[t] = co
 }
 
+// DefineOption sets a named option that changes the behavior of filters
+// evaluated in this context.
+func (c *Context) DefineOption(name string, v interface{}) {
+	c.options[name] = v
+}
+
+// Option retrieves the value of a named option, or nil if the option has not
+// been set.
+func (c *Context) Option(name string) interface{} {
+	if v, ok := c.options[name]; ok {
+		return v
+	}
+
+	if c.next != nil {
+		return c.next.Option(name)
+	}
+
+	return nil
+}
+
 func OverlayContext(ctx *Context) *Context {
 	return &Context{
 		variables:  make(map[string]Valuer),
 		functions:  make(map[string]map[int]Function),
 		converters: make(map[reflect.Type]Converter),
+		options:    make(map[string]interface{}),
 		next:       ctx,
 	}
 }
@@ -0,0 +1,30 @@
+package context
+
+// Dialect selects between the native semantics of filq and those of jq where
+// the two differ.
+type Dialect int
+
+const (
+	// DialectFilq is the default dialect. Expanding an object produces its
+	// entries, selecting from null is an error and slices include their
+	// upper bound.
+	DialectFilq Dialect = iota
+
+	// DialectJQ follows jq: expanding an object produces its values,
+	// selecting from null produces null and slices exclude their upper bound
+	// and count negative bounds from the end.
+	DialectJQ
+)
+
+const optionDialect = "dialect"
+
+// SetDialect sets the dialect used by filters evaluated in this context.
+func (c *Context) SetDialect(d Dialect) {
+	c.DefineOption(optionDialect, d)
+}
+
+// Dialect returns the dialect used by filters evaluated in this context.
+func (c *Context) Dialect() Dialect {
+	d, _ := c.Option(optionDialect).(Dialect)
+	return d
+}
@@ -9,11 +9,24 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/reflect/filq"
+	"github.com/reflect/filq/context"
 	fj "github.com/reflect/filq/lib/json"
+	"github.com/reflect/filq/types"
+)
+
+var (
+	sortKeys  = flag.Bool("S", false, "sort the keys of objects on output")
+	jqDialect = flag.Bool("jq", false, "use jq semantics where they differ from filq")
 )
 
 func run() error {
 	ctx := filq.NewContext()
+	ctx.DefineOption(types.OptionSortKeys, *sortKeys)
+
+	if *jqDialect {
+		ctx.SetDialect(context.DialectJQ)
+	}
+
 	filter, err := filq.NewParser().ParseString(flag.Arg(0))
 	if err != nil {
 		return err
@@ -40,6 +53,10 @@ func run() error {
 				return err
 			}
 
+			if *sortKeys {
+				v = types.SortKeys(v)
+			}
+
 			fmt.Printf("%+v\n", v)
 		}
 	}
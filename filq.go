@@ -3,10 +3,25 @@ package filq
 import (
 	"github.com/reflect/filq/context"
 	"github.com/reflect/filq/function"
+	"github.com/reflect/filq/lib/cbor"
+	"github.com/reflect/filq/lib/compress"
+	"github.com/reflect/filq/lib/config"
+	"github.com/reflect/filq/lib/csv"
+	"github.com/reflect/filq/lib/encoding"
+	"github.com/reflect/filq/lib/hash"
 	"github.com/reflect/filq/lib/io"
 	"github.com/reflect/filq/lib/json"
+	"github.com/reflect/filq/lib/jsonpatch"
+	"github.com/reflect/filq/lib/jwt"
+	"github.com/reflect/filq/lib/msgpack"
+	"github.com/reflect/filq/lib/net"
 	"github.com/reflect/filq/lib/regex"
+	"github.com/reflect/filq/lib/semver"
 	"github.com/reflect/filq/lib/time"
+	"github.com/reflect/filq/lib/url"
+	"github.com/reflect/filq/lib/uuid"
+	"github.com/reflect/filq/lib/xml"
+	"github.com/reflect/filq/lib/yaml"
 	"github.com/reflect/filq/parser"
 	"github.com/reflect/filq/types"
 )
@@ -23,10 +38,25 @@ func NewContext() *context.Context {
 	types.DefineIn(def)
 
 	// Standard library.
+	cbor.DefineIn(def)
+	compress.DefineIn(def)
+	config.DefineIn(def)
+	csv.DefineIn(def)
+	encoding.DefineIn(def)
+	hash.DefineIn(def)
 	io.DefineIn(def)
 	json.DefineIn(def)
+	jsonpatch.DefineIn(def)
+	jwt.DefineIn(def)
+	msgpack.DefineIn(def)
+	net.DefineIn(def)
 	regex.DefineIn(def)
+	semver.DefineIn(def)
 	time.DefineIn(def)
+	url.DefineIn(def)
+	uuid.DefineIn(def)
+	xml.DefineIn(def)
+	yaml.DefineIn(def)
 
 	return def
 }
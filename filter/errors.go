@@ -11,3 +11,20 @@ type CannotSubscriptError struct {
 func (e *CannotSubscriptError) Error() string {
 	return fmt.Sprintf("filter returned %d outputs (wanted exactly 1)", e.Dimensions)
 }
+
+type DivisionByZeroError struct {
+	Dividend interface{}
+}
+
+func (e *DivisionByZeroError) Error() string {
+	return fmt.Sprintf("%v cannot be divided because the divisor is zero", e.Dividend)
+}
+
+type DurationRangeError struct {
+	Left, Right interface{}
+	Operator    string
+}
+
+func (e *DurationRangeError) Error() string {
+	return fmt.Sprintf("%v %s %v: duration out of range", e.Left, e.Operator, e.Right)
+}
@@ -68,7 +68,7 @@ func (o *Object) Apply(ctx *context.Context, in context.Valuer) ([]context.Value
 		mi := kvs[i]
 
 		lazy := func(ctx *context.Context) (interface{}, error) {
-			m := make(map[string]interface{})
+			o := types.NewObject()
 
 			for _, entry := range mi {
 				key, err := entry.Key.Value(ctx)
@@ -96,10 +96,10 @@ func (o *Object) Apply(ctx *context.Context, in context.Valuer) ([]context.Value
 					})
 				}
 
-				m[ks] = value
+				o.Set(ks, value)
 			}
 
-			return ctx.Convert(m), nil
+			return o, nil
 		}
 
 		vrs[i] = context.NewLazyValuer(lazy)
@@ -34,7 +34,7 @@ func (o *Op2) Apply(ctx *context.Context, in context.Valuer) ([]context.Valuer,
 			case "+":
 				out[k] = &op2AddFilter{l: lv, r: rv}
 			case "-":
-				out[k] = &op2NumFilter{fn: op2Sub, l: lv, r: rv}
+				out[k] = &op2SubFilter{l: lv, r: rv}
 			case "<":
 				out[k] = &op2CmpFilter{fn: op2Lt, l: lv, r: rv}
 			case "<=":
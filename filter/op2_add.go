@@ -27,26 +27,38 @@ func (f *op2AddFilter) Value(ctx *context.Context) (interface{}, error) {
 	var out interface{}
 
 	switch lt := lv.(type) {
-	case int64:
-		rt, ok := rv.(int64)
+	case types.Int, types.Float, types.Number:
+		return op2Add.Apply(ctx, lv, rv)
+	case types.Time:
+		rt, ok := rv.(types.Duration)
 		if !ok {
 			return nil, errors.WithStack(&context.UnexpectedTypeError{
-				Wanted: []reflect.Type{reflect.TypeOf(int64(0))},
+				Wanted: []reflect.Type{reflect.TypeOf(types.Duration{})},
 				Got:    reflect.TypeOf(rv),
 			})
 		}
 
-		out = lt + rt
-	case float64:
-		rt, ok := rv.(float64)
-		if !ok {
+		out = types.Time{Time: lt.Add(rt.Duration)}
+	case types.Duration:
+		switch rt := rv.(type) {
+		case types.Duration:
+			d := lt.Duration + rt.Duration
+			if (rt.Duration > 0 && d < lt.Duration) || (rt.Duration < 0 && d > lt.Duration) {
+				return nil, errors.WithStack(&DurationRangeError{Left: lt, Right: rt, Operator: "+"})
+			}
+
+			out = types.Duration{Duration: d}
+		case types.Time:
+			out = types.Time{Time: rt.Add(lt.Duration)}
+		default:
 			return nil, errors.WithStack(&context.UnexpectedTypeError{
-				Wanted: []reflect.Type{reflect.TypeOf(float64(0))},
-				Got:    reflect.TypeOf(rv),
+				Wanted: []reflect.Type{
+					reflect.TypeOf(types.Duration{}),
+					reflect.TypeOf(types.Time{}),
+				},
+				Got: reflect.TypeOf(rv),
 			})
 		}
-
-		out = lt + rt
 	case types.Str:
 		switch rt := rv.(type) {
 		case types.Str:
@@ -89,10 +101,13 @@ func (f *op2AddFilter) Value(ctx *context.Context) (interface{}, error) {
 	default:
 		return nil, errors.WithStack(&context.UnexpectedTypeError{
 			Wanted: []reflect.Type{
-				reflect.TypeOf(int64(0)),
-				reflect.TypeOf(float64(0)),
+				reflect.TypeOf(types.Int(0)),
+				reflect.TypeOf(types.Float(0)),
+				reflect.TypeOf(types.Number("")),
 				reflect.TypeOf(types.Str("")),
 				reflect.TypeOf(types.Bytes([]byte{})),
+				reflect.TypeOf(types.Time{}),
+				reflect.TypeOf(types.Duration{}),
 			},
 			Got: reflect.TypeOf(lv),
 		})
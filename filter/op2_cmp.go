@@ -1,10 +1,8 @@
 package filter
 
 import (
-	"reflect"
-
-	"github.com/pkg/errors"
 	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
 )
 
 type op2CmpFunc func(cmp int) bool
@@ -25,17 +23,12 @@ func (f *op2CmpFilter) Value(ctx *context.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	lc, ok := lv.(context.Cmp)
-	if !ok {
-		return nil, errors.WithStack(&context.UnexpectedTypeError{
-			Wanted: []reflect.Type{
-				reflect.TypeOf((*context.Cmp)(nil)).Elem(),
-			},
-			Got: reflect.TypeOf(lv),
-		})
+	rv, err := f.r.Value(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	cmp, err := lc.Compare(ctx, f.r)
+	cmp, err := types.Compare(ctx, lv, rv)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package filter
 
 import (
 	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
 )
 
 type op2EqualFilter struct {
@@ -15,19 +16,14 @@ func (f *op2EqualFilter) Value(ctx *context.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	var equal bool
-	if eq, ok := lv.(context.Eq); ok {
-		equal, err = eq.Equal(ctx, f.r)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		rv, err := f.r.Value(ctx)
-		if err != nil {
-			return nil, err
-		}
+	rv, err := f.r.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		equal = rv == lv
+	equal, err := types.Equal(ctx, lv, rv)
+	if err != nil {
+		return nil, err
 	}
 
 	if f.inverse {
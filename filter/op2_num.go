@@ -1,6 +1,8 @@
 package filter
 
 import (
+	"math"
+	"math/big"
 	"reflect"
 
 	"github.com/pkg/errors"
@@ -8,31 +10,130 @@ import (
 	"github.com/reflect/filq/types"
 )
 
+// op2NumFunc implements an arithmetic operator for each of the numeric types.
+// Integer implementations return ok = false if the result cannot be
+// represented as an integer, in which case the operation is retried using
+// floats. Rational implementations are only used for decimal arithmetic.
 type op2NumFunc struct {
-	fnI func(a, b types.Int) interface{}
-	fnF func(a, b types.Float) interface{}
+	fnI func(a, b types.Int) (r interface{}, ok bool, err error)
+	fnF func(a, b types.Float) (interface{}, error)
+	fnR func(a, b *big.Rat) (*big.Rat, error)
 }
 
-func (f op2NumFunc) ApplyInt(a, b types.Int) interface{} {
-	return f.fnI(a, b)
-}
+func (f op2NumFunc) Apply(ctx *context.Context, a, b interface{}) (interface{}, error) {
+	an, aok := a.(types.Number)
+	bn, bok := b.(types.Number)
+	if aok || bok {
+		if decimal, _ := ctx.Option(types.OptionDecimal).(bool); decimal {
+			ar, aok := types.ToRat(a)
+			br, bok := types.ToRat(b)
+			if aok && bok {
+				r, err := f.fnR(ar, br)
+				if err != nil {
+					return nil, err
+				}
+
+				return types.NewRatNumber(r), nil
+			}
+		}
+
+		if aok {
+			a = an.Demote()
+		}
+
+		if bok {
+			b = bn.Demote()
+		}
+	}
+
+	pa, pb, ok := types.PromoteNumbers(a, b)
+	if !ok {
+		got := b
+		if _, _, ok := types.PromoteNumbers(a, a); !ok {
+			got = a
+		}
+
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(types.Int(0)),
+				reflect.TypeOf(types.Float(0)),
+				reflect.TypeOf(types.Number("")),
+			},
+			Got: reflect.TypeOf(got),
+		})
+	}
+
+	if ai, ok := pa.(types.Int); ok {
+		bi := pb.(types.Int)
+
+		r, ok, err := f.fnI(ai, bi)
+		if err != nil || ok {
+			return r, err
+		}
 
-func (f op2NumFunc) ApplyFloat(a, b types.Float) interface{} {
-	return f.fnF(a, b)
+		pa, pb = types.Float(ai), types.Float(bi)
+	}
+
+	return f.fnF(pa.(types.Float), pb.(types.Float))
 }
 
 var (
+	op2Add = op2NumFunc{
+		fnI: func(a, b types.Int) (interface{}, bool, error) {
+			c := a + b
+			return c, (c > a) == (b > 0), nil
+		},
+		fnF: func(a, b types.Float) (interface{}, error) { return a + b, nil },
+		fnR: func(a, b *big.Rat) (*big.Rat, error) { return new(big.Rat).Add(a, b), nil },
+	}
 	op2Mul = op2NumFunc{
-		fnI: func(a, b types.Int) interface{} { return a * b },
-		fnF: func(a, b types.Float) interface{} { return a * b },
+		fnI: func(a, b types.Int) (interface{}, bool, error) {
+			if a == 0 || b == 0 {
+				return types.Int(0), true, nil
+			}
+
+			c := a * b
+			return c, c/b == a && !(a == math.MinInt64 && b == -1), nil
+		},
+		fnF: func(a, b types.Float) (interface{}, error) { return a * b, nil },
+		fnR: func(a, b *big.Rat) (*big.Rat, error) { return new(big.Rat).Mul(a, b), nil },
 	}
 	op2Div = op2NumFunc{
-		fnI: func(a, b types.Int) interface{} { return a / b },
-		fnF: func(a, b types.Float) interface{} { return a / b },
+		fnI: func(a, b types.Int) (interface{}, bool, error) {
+			if b == 0 {
+				return nil, false, errors.WithStack(&DivisionByZeroError{Dividend: a})
+			}
+
+			// Like jq, division only produces an integer if the result is
+			// exact.
+			if a%b != 0 || (a == math.MinInt64 && b == -1) {
+				return nil, false, nil
+			}
+
+			return a / b, true, nil
+		},
+		fnF: func(a, b types.Float) (interface{}, error) {
+			if b == 0 {
+				return nil, errors.WithStack(&DivisionByZeroError{Dividend: a})
+			}
+
+			return a / b, nil
+		},
+		fnR: func(a, b *big.Rat) (*big.Rat, error) {
+			if b.Sign() == 0 {
+				return nil, errors.WithStack(&DivisionByZeroError{Dividend: types.NewRatNumber(a)})
+			}
+
+			return new(big.Rat).Quo(a, b), nil
+		},
 	}
 	op2Sub = op2NumFunc{
-		fnI: func(a, b types.Int) interface{} { return a - b },
-		fnF: func(a, b types.Float) interface{} { return a - b },
+		fnI: func(a, b types.Int) (interface{}, bool, error) {
+			c := a - b
+			return c, (c < a) == (b > 0), nil
+		},
+		fnF: func(a, b types.Float) (interface{}, error) { return a - b, nil },
+		fnR: func(a, b *big.Rat) (*big.Rat, error) { return new(big.Rat).Sub(a, b), nil },
 	}
 )
 
@@ -52,34 +153,5 @@ func (f *op2NumFilter) Value(ctx *context.Context) (interface{}, error) {
 		return nil, err
 	}
 
-	var out interface{}
-
-	if li, ok := lv.(types.Int); ok {
-		ri, ok := rv.(types.Int)
-		if !ok {
-			return nil, errors.WithStack(&context.UnexpectedTypeError{
-				Wanted: []reflect.Type{reflect.TypeOf(types.Int(0))},
-				Got:    reflect.TypeOf(rv),
-			})
-		}
-
-		out = f.fn.fnI(li, ri)
-	} else if lf, ok := lv.(types.Float); ok {
-		rf, ok := rv.(types.Float)
-		if !ok {
-			return nil, errors.WithStack(&context.UnexpectedTypeError{
-				Wanted: []reflect.Type{reflect.TypeOf(types.Float(0))},
-				Got:    reflect.TypeOf(rv),
-			})
-		}
-
-		out = f.fn.fnF(lf, rf)
-	} else {
-		return nil, errors.WithStack(&context.UnexpectedTypeError{
-			Wanted: []reflect.Type{reflect.TypeOf(types.Int(0)), reflect.TypeOf(types.Float(0))},
-			Got:    reflect.TypeOf(lv),
-		})
-	}
-
-	return out, nil
+	return f.fn.Apply(ctx, lv, rv)
 }
@@ -0,0 +1,138 @@
+package filter
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOp2Num(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+
+	conds := []struct {
+		Fn          op2NumFunc
+		Left, Right interface{}
+		Expected    interface{}
+	}{
+		{op2Add, types.Int(1), types.Int(2), types.Int(3)},
+		{op2Add, types.Int(1), types.Float(0.5), types.Float(1.5)},
+		{op2Add, types.Int(math.MaxInt64), types.Int(1), types.Float(math.MaxInt64) + 1},
+		{op2Sub, types.Float(2.5), types.Int(1), types.Float(1.5)},
+		{op2Sub, types.Int(math.MinInt64), types.Int(1), types.Float(math.MinInt64) - 1},
+		{op2Mul, types.Int(6), types.Int(7), types.Int(42)},
+		{op2Mul, types.Int(math.MinInt64), types.Int(-1), -types.Float(math.MinInt64)},
+		{op2Div, types.Int(6), types.Int(3), types.Int(2)},
+		{op2Div, types.Int(1), types.Int(2), types.Float(0.5)},
+		{op2Div, types.Float(1), types.Int(4), types.Float(0.25)},
+	}
+
+	for _, cond := range conds {
+		r, err := cond.Fn.Apply(ctx, cond.Left, cond.Right)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, r)
+	}
+
+	_, err := op2Div.Apply(ctx, types.Int(1), types.Int(0))
+	assert.EqualError(t, err, "1 cannot be divided because the divisor is zero")
+
+	_, err = op2Div.Apply(ctx, types.Float(1), types.Float(0))
+	assert.EqualError(t, err, "1 cannot be divided because the divisor is zero")
+
+	_, err = op2Mul.Apply(ctx, types.Str("a"), types.Int(1))
+	assert.EqualError(t, err, "unexpected type types.Str (wanted one of [types.Int types.Float types.Number])")
+}
+
+func TestOp2MixedComparison(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	eq := &op2EqualFilter{l: context.NewConstValuer(int64(1)), r: context.NewConstValuer(1.0)}
+	v, err := eq.Value(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, true, v)
+
+	eq = &op2EqualFilter{l: context.NewConstValuer(math.NaN()), r: context.NewConstValuer(math.NaN())}
+	v, err = eq.Value(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, false, v)
+
+	lt := &op2CmpFilter{fn: op2Lt, l: context.NewConstValuer(1.5), r: context.NewConstValuer(int64(2))}
+	v, err = lt.Value(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, true, v)
+
+	// 2^53 + 1 cannot be represented as a float, so it must not compare equal
+	// to 2^53.
+	gt := &op2CmpFilter{fn: op2Gt, l: context.NewConstValuer(int64(1<<53 + 1)), r: context.NewConstValuer(float64(1 << 53))}
+	v, err = gt.Value(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, true, v)
+}
+
+func TestOp2NumDecimal(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+
+	// Without decimal arithmetic, Numbers are demoted.
+	r, err := op2Add.Apply(ctx, types.Number("0.1"), types.Number("0.2"))
+	assert.NoError(t, err)
+	assert.Equal(t, types.Float(0.1)+types.Float(0.2), r)
+
+	r, err = op2Add.Apply(ctx, types.Number("9007199254740993"), types.Int(1))
+	assert.NoError(t, err)
+	assert.Equal(t, types.Int(9007199254740994), r)
+
+	types.DefineNumbersIn(ctx, true)
+
+	conds := []struct {
+		Fn          op2NumFunc
+		Left, Right interface{}
+		Expected    types.Number
+	}{
+		{op2Add, types.Number("0.1"), types.Number("0.2"), "0.3"},
+		{op2Add, types.Number("123456789012345678901234567890"), types.Int(1), "123456789012345678901234567891"},
+		{op2Sub, types.Number("1.10"), types.Float(0.1), "0.9999999999999999944488848768742172978818416595458984375"},
+		{op2Mul, types.Number("2.5"), types.Number("4"), "10"},
+		{op2Div, types.Number("1"), types.Number("8"), "0.125"},
+		{op2Div, types.Number("2"), types.Number("3"), "0.6666666666666666666666666666666667"},
+	}
+
+	for _, cond := range conds {
+		r, err := cond.Fn.Apply(ctx, cond.Left, cond.Right)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, r)
+	}
+
+	_, err = op2Div.Apply(ctx, types.Number("1.5"), types.Int(0))
+	assert.EqualError(t, err, "1.5 cannot be divided because the divisor is zero")
+}
+
+func TestOp2DurationRange(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+
+	apply := func(f context.Valuer) (interface{}, error) { return f.Value(ctx) }
+	d := func(d time.Duration) context.Valuer { return context.NewConstValuer(types.Duration{Duration: d}) }
+
+	r, err := apply(&op2AddFilter{l: d(time.Hour), r: d(time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, types.Duration{Duration: time.Hour + time.Minute}, r)
+
+	r, err = apply(&op2SubFilter{l: d(math.MinInt64 + 1), r: d(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, types.Duration{Duration: math.MinInt64}, r)
+
+	for _, f := range []context.Valuer{
+		&op2AddFilter{l: d(math.MaxInt64), r: d(1)},
+		&op2AddFilter{l: d(math.MinInt64), r: d(-1)},
+		&op2SubFilter{l: d(math.MinInt64), r: d(1)},
+		&op2SubFilter{l: d(math.MaxInt64), r: d(-1)},
+		&op2SubFilter{l: d(0), r: d(math.MinInt64)},
+	} {
+		_, err := apply(f)
+		assert.IsType(t, &DurationRangeError{}, errors.Cause(err))
+	}
+}
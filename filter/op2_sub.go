@@ -0,0 +1,64 @@
+package filter
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+type op2SubFilter struct {
+	l, r context.Valuer
+}
+
+func (f *op2SubFilter) Value(ctx *context.Context) (interface{}, error) {
+	lv, err := f.l.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rv, err := f.r.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var out interface{}
+
+	switch lt := lv.(type) {
+	case types.Time:
+		switch rt := rv.(type) {
+		case types.Time:
+			out = types.Duration{Duration: lt.Sub(rt.Time)}
+		case types.Duration:
+			out = types.Time{Time: lt.Add(-rt.Duration)}
+		default:
+			return nil, errors.WithStack(&context.UnexpectedTypeError{
+				Wanted: []reflect.Type{
+					reflect.TypeOf(types.Time{}),
+					reflect.TypeOf(types.Duration{}),
+				},
+				Got: reflect.TypeOf(rv),
+			})
+		}
+	case types.Duration:
+		rt, ok := rv.(types.Duration)
+		if !ok {
+			return nil, errors.WithStack(&context.UnexpectedTypeError{
+				Wanted: []reflect.Type{reflect.TypeOf(types.Duration{})},
+				Got:    reflect.TypeOf(rv),
+			})
+		}
+
+		d := lt.Duration - rt.Duration
+		if (rt.Duration > 0 && d > lt.Duration) || (rt.Duration < 0 && d < lt.Duration) {
+			return nil, errors.WithStack(&DurationRangeError{Left: lt, Right: rt, Operator: "-"})
+		}
+
+		out = types.Duration{Duration: d}
+	default:
+		return op2Sub.Apply(ctx, lv, rv)
+	}
+
+	return out, nil
+}
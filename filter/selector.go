@@ -52,7 +52,9 @@ func (s *Selector) Apply(ctx *context.Context, in context.Valuer) ([]context.Val
 		}
 
 		selector, ok := v.(context.Sel)
-		if !ok {
+		if !ok && v == nil && ctx.Dialect() == context.DialectJQ {
+			return []context.Valuer{context.NewConstValuer(nil)}, nil
+		} else if !ok {
 			return nil, errors.WithStack(&context.UnexpectedTypeError{
 				Wanted: []reflect.Type{
 					reflect.TypeOf((*context.Sel)(nil)).Elem(),
@@ -0,0 +1,55 @@
+package function
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func init() {
+	fn, _ := NewFunction(Sort)
+	register("sort", fn)
+}
+
+// Sort orders the elements of an array using types.Compare. The sort is
+// stable, so elements that compare equal keep their relative order.
+func Sort(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a, ok := v.(types.Array)
+	if !ok {
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{reflect.TypeOf(types.Array{})},
+			Got:    reflect.TypeOf(v),
+		})
+	}
+
+	out := make(types.Array, len(a))
+	copy(out, a)
+
+	var cerr error
+	sort.SliceStable(out, func(i, j int) bool {
+		if cerr != nil {
+			return false
+		}
+
+		c, err := types.Compare(ctx, out[i], out[j])
+		if err != nil {
+			cerr = err
+			return false
+		}
+
+		return c < 0
+	})
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	return []context.Valuer{context.NewConstValuer(out)}, nil
+}
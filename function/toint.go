@@ -30,11 +30,18 @@ func ToInt(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
 		}
 	} else if f, ok := v.(types.Float); ok {
 		r = int64(f)
+	} else if n, ok := v.(types.Number); ok {
+		r, err = strconv.ParseInt(string(n), 10, 64)
+		if err != nil {
+			f, _ := strconv.ParseFloat(string(n), 64)
+			r = int64(f)
+		}
 	} else {
 		return nil, errors.WithStack(&context.UnexpectedTypeError{
 			Wanted: []reflect.Type{
 				reflect.TypeOf(types.Int(0)),
 				reflect.TypeOf(types.Float(0)),
+				reflect.TypeOf(types.Number("")),
 				reflect.TypeOf(types.Str("")),
 			},
 			Got: reflect.TypeOf(v),
@@ -0,0 +1,106 @@
+package function
+
+import (
+	"fmt"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// typeFilters maps the names of the type-selecting builtins to the type names
+// they pass through. If inverse is set, the filter instead passes through
+// everything except the given types.
+var typeFilters = map[string]struct {
+	names   []string
+	inverse bool
+}{
+	"arrays":    {names: []string{"array"}},
+	"objects":   {names: []string{"object"}},
+	"iterables": {names: []string{"array", "object"}},
+	"booleans":  {names: []string{"boolean"}},
+	"numbers":   {names: []string{"number"}},
+	"strings":   {names: []string{"string"}},
+	"nulls":     {names: []string{"null"}},
+	"values":    {names: []string{"null"}, inverse: true},
+	"scalars":   {names: []string{"array", "object"}, inverse: true},
+}
+
+func init() {
+	fn, _ := NewFunction(Type)
+	register("type", fn)
+
+	for name, tf := range typeFilters {
+		fn, _ := NewFunction(newTypeFilter(tf.names, tf.inverse))
+		register(name, fn)
+	}
+}
+
+// TypeName returns the name of the type of the given value. Types that have an
+// equivalent in jq use the same name; the remaining types use names specific
+// to filq.
+func TypeName(ctx *context.Context, v interface{}) string {
+	switch vt := ctx.Convert(v).(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case types.Int, types.Float, types.Number:
+		return "number"
+	case types.Str:
+		return "string"
+	case types.Bytes:
+		return "bytes"
+	case types.Array:
+		return "array"
+	case *types.Object:
+		return "object"
+	case types.Entry:
+		return "entry"
+	case types.Time:
+		return "time"
+	case types.Duration:
+		return "duration"
+	case types.IP:
+		return "ip"
+	case types.Semver:
+		return "semver"
+	case types.Slice:
+		return "slice"
+	default:
+		return fmt.Sprintf("%T", vt)
+	}
+}
+
+func Type(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(types.Str(TypeName(ctx, v)))}, nil
+}
+
+func newTypeFilter(names []string, inverse bool) func(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return func(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+		v, err := in.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		tn := TypeName(ctx, v)
+
+		match := false
+		for _, name := range names {
+			if tn == name {
+				match = true
+				break
+			}
+		}
+
+		if match == inverse {
+			return []context.Valuer{}, nil
+		}
+
+		return []context.Valuer{in}, nil
+	}
+}
@@ -0,0 +1,77 @@
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestType(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	conds := []struct {
+		Value    interface{}
+		Expected string
+	}{
+		{nil, "null"},
+		{true, "boolean"},
+		{int64(42), "number"},
+		{4.2, "number"},
+		{"foo", "string"},
+		{[]byte("foo"), "bytes"},
+		{[]interface{}{1, 2}, "array"},
+		{map[string]interface{}{"a": 1}, "object"},
+		{types.Entry{Key: "a", Value: 1}, "entry"},
+		{time.Unix(0, 0), "time"},
+	}
+
+	for _, cond := range conds {
+		l, err := Type(ctx, context.NewConstValuer(cond.Value))
+		assert.NoError(t, err)
+		assert.Equal(t, []context.Valuer{context.NewConstValuer(types.Str(cond.Expected))}, l)
+	}
+}
+
+func TestTypeFilters(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+	DefineIn(ctx)
+
+	conds := []struct {
+		Filter   string
+		Value    interface{}
+		Expected bool
+	}{
+		{"arrays", []interface{}{}, true},
+		{"arrays", map[string]interface{}{}, false},
+		{"iterables", map[string]interface{}{}, true},
+		{"iterables", "foo", false},
+		{"numbers", 1.5, true},
+		{"strings", []byte("foo"), false},
+		{"nulls", nil, true},
+		{"values", nil, false},
+		{"values", false, true},
+		{"scalars", "foo", true},
+		{"scalars", []interface{}{}, false},
+	}
+
+	for _, cond := range conds {
+		fn, err := ctx.Function(cond.Filter, 0)
+		assert.NoError(t, err)
+
+		in := context.NewConstValuer(cond.Value)
+
+		l, err := fn.Call(ctx, in, nil)
+		assert.NoError(t, err)
+
+		if cond.Expected {
+			assert.Equal(t, []context.Valuer{in}, l, cond.Filter)
+		} else {
+			assert.Empty(t, l, cond.Filter)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Package cbor converts between CBOR (RFC 8949) and filq values.
+//
+// Text strings decode to types.Str and byte strings to types.Bytes, and
+// likewise on encoding. Standard date/time strings (tag 0) and epoch-based
+// dates (tag 1) decode to times, and bignums (tags 2 and 3) to numbers. Other
+// tags are ignored and only their content is kept. Undefined decodes to null.
+// Map keys that are not strings are stored as their JSON representation.
+package cbor
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+const (
+	majorUint = iota
+	majorNegInt
+	majorBytes
+	majorText
+	majorArray
+	majorMap
+	majorTag
+	majorSimple
+)
+
+const (
+	tagDateTime  = 0
+	tagEpoch     = 1
+	tagBignum    = 2
+	tagNegBignum = 3
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(FromCBOR)
+	ctx.DefineFunction("fromcbor", fn)
+
+	fn, _ = function.NewFunction(ToCBOR)
+	ctx.DefineFunction("tocbor", fn)
+}
@@ -0,0 +1,91 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	ab := types.NewObject()
+	ab.Set("a", int64(1))
+	ab.Set("b", []interface{}{int64(2), int64(3)})
+
+	// Examples from RFC 8949, appendix A.
+	conds := []struct {
+		Hex      string
+		Expected interface{}
+	}{
+		{"1bffffffffffffffff", json.Number("18446744073709551615")},
+		{"3bffffffffffffffff", json.Number("-18446744073709551616")},
+		{"c249010000000000000000", json.Number("18446744073709551616")},
+		{"3863", int64(-100)},
+		{"f93c00", 1.0},
+		{"f97bff", 65504.0},
+		{"fb3ff199999999999a", 1.1},
+		{"c074323031332d30332d32315432303a30343a30305a", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{"c11a514b67b0", time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC)},
+		{"5f42010243030405ff", []byte{1, 2, 3, 4, 5}},
+		{"7f657374726561646d696e67ff", "streaming"},
+		{"bf61610161629f0203ffff", ab},
+		{"f7", nil},
+	}
+
+	for _, cond := range conds {
+		b, _ := hex.DecodeString(cond.Hex)
+
+		values, err := decode(bytes.NewReader(b))
+		assert.NoError(t, err, cond.Hex)
+
+		if assert.Len(t, values, 1, cond.Hex) {
+			if expected, ok := cond.Expected.(time.Time); ok {
+				assert.True(t, expected.Equal(values[0].(time.Time)), cond.Hex)
+			} else {
+				assert.Equal(t, cond.Expected, values[0], cond.Hex)
+			}
+		}
+	}
+
+	for _, invalid := range []string{"18", "62616263", "ff", "9f01", "1c", "5b000000000000000561"} {
+		b, _ := hex.DecodeString(invalid)
+
+		_, err := decode(bytes.NewReader(b))
+		assert.Error(t, err, invalid)
+	}
+
+	// Lengths of 2^63 or more do not fit in an int64.
+	for _, invalid := range []string{"5bffffffffffffffff", "7b8000000000000000", "5f5bffffffffffffffffff"} {
+		b, _ := hex.DecodeString(invalid)
+
+		_, err := decode(bytes.NewReader(b))
+		assert.IsType(t, &InvalidItemError{}, errors.Cause(err), invalid)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	o := types.NewObject()
+	o.Set("z", []interface{}{int64(-1), 1.5, "s", []byte{0xff}, nil, true})
+	o.Set("a", types.Number("-18446744073709551617"))
+
+	var e encoder
+	assert.NoError(t, e.value(ctx, o))
+	assert.NoError(t, e.value(ctx, int64(2)))
+
+	values, err := decode(&e.b)
+	assert.NoError(t, err)
+	assert.Len(t, values, 2)
+
+	b, err := json.Marshal(values)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"z":[-1,1.5,"s","/w==",null,true],"a":-18446744073709551617},2]`, string(b))
+}
@@ -0,0 +1,22 @@
+package cbor
+
+import (
+	"fmt"
+)
+
+type InvalidItemError struct {
+	Initial byte
+	Reason  string
+}
+
+func (e *InvalidItemError) Error() string {
+	return fmt.Sprintf("invalid CBOR item with initial byte 0x%02x: %s", e.Initial, e.Reason)
+}
+
+type NestingError struct {
+	Depth int
+}
+
+func (e *NestingError) Error() string {
+	return fmt.Sprintf("values nested more than %d levels deep", e.Depth)
+}
@@ -0,0 +1,332 @@
+package cbor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// maxDepth limits the nesting of arrays, maps and tags so that malicious
+// input cannot exhaust the stack.
+const maxDepth = 1000
+
+// errBreak is returned when a break stop code is read. It is only valid
+// inside indefinite-length items.
+var errBreak = errors.New("unexpected break")
+
+type decoder struct {
+	r *bufio.Reader
+}
+
+// head reads the initial byte of an item and its argument. For
+// indefinite-length items, indefinite is set instead.
+func (d *decoder) head() (ib byte, major byte, arg uint64, indefinite bool, err error) {
+	ib, err = d.r.ReadByte()
+	if err != nil {
+		return
+	}
+
+	major, info := ib>>5, ib&0x1f
+
+	switch {
+	case info < 24:
+		arg = uint64(info)
+	case info <= 27:
+		n := 1 << (info - 24)
+
+		var b [8]byte
+		if _, err = io.ReadFull(d.r, b[8-n:]); err != nil {
+			return
+		}
+
+		arg = binary.BigEndian.Uint64(b[:])
+	case info == 31 && major != majorUint && major != majorNegInt && major != majorTag:
+		indefinite = true
+	default:
+		err = &InvalidItemError{Initial: ib, Reason: "reserved additional information"}
+	}
+
+	return
+}
+
+// bytes reads n bytes without allocating all of them up front, since the
+// length comes from untrusted input.
+func (d *decoder) bytes(ib byte, n uint64) ([]byte, error) {
+	if n > math.MaxInt64 {
+		return nil, &InvalidItemError{Initial: ib, Reason: "length out of range"}
+	}
+
+	var b bytes.Buffer
+	if _, err := io.CopyN(&b, d.r, int64(n)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// chunks reads the definite-length chunks of an indefinite-length string.
+func (d *decoder) chunks(ib, major byte) ([]byte, error) {
+	var out []byte
+	for {
+		cib, cmajor, n, indefinite, err := d.head()
+		if err != nil {
+			return nil, err
+		}
+
+		if cib == 0xff {
+			return out, nil
+		}
+
+		if cmajor != major || indefinite {
+			return nil, &InvalidItemError{Initial: ib, Reason: "invalid chunk in indefinite-length string"}
+		}
+
+		b, err := d.bytes(cib, n)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, b...)
+	}
+}
+
+func (d *decoder) tagged(ib byte, tag uint64, depth int) (interface{}, error) {
+	v, err := d.value(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case tagDateTime:
+		if s, ok := v.(string); ok {
+			t, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return nil, &InvalidItemError{Initial: ib, Reason: err.Error()}
+			}
+
+			return t, nil
+		}
+	case tagEpoch:
+		switch vt := v.(type) {
+		case int64:
+			return time.Unix(vt, 0).UTC(), nil
+		case float64:
+			whole, frac := math.Modf(vt)
+			return time.Unix(int64(whole), int64(frac*1e9)).UTC(), nil
+		}
+	case tagBignum, tagNegBignum:
+		if b, ok := v.([]byte); ok {
+			i := new(big.Int).SetBytes(b)
+			if tag == tagNegBignum {
+				i.Neg(i).Sub(i, big.NewInt(1))
+			}
+
+			return json.Number(i.String()), nil
+		}
+	default:
+		return v, nil
+	}
+
+	return nil, &InvalidItemError{Initial: ib, Reason: "unexpected content for tag " + strconv.FormatUint(tag, 10)}
+}
+
+func (d *decoder) key(depth int) (string, error) {
+	kv, err := d.value(depth + 1)
+	if err != nil {
+		return "", err
+	}
+
+	if key, ok := kv.(string); ok {
+		return key, nil
+	}
+
+	b, err := json.Marshal(types.SortKeys(kv))
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func (d *decoder) value(depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, &NestingError{Depth: maxDepth}
+	}
+
+	ib, major, arg, indefinite, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case majorUint:
+		if arg > math.MaxInt64 {
+			return json.Number(strconv.FormatUint(arg, 10)), nil
+		}
+
+		return int64(arg), nil
+	case majorNegInt:
+		if arg > math.MaxInt64 {
+			i := new(big.Int).SetUint64(arg)
+			return json.Number(i.Neg(i).Sub(i, big.NewInt(1)).String()), nil
+		}
+
+		return -1 - int64(arg), nil
+	case majorBytes, majorText:
+		var b []byte
+		if indefinite {
+			b, err = d.chunks(ib, major)
+		} else {
+			b, err = d.bytes(ib, arg)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if major == majorText {
+			return string(b), nil
+		}
+
+		return b, nil
+	case majorArray:
+		a := []interface{}{}
+		for i := uint64(0); indefinite || i < arg; i++ {
+			v, err := d.value(depth + 1)
+			if err == errBreak && indefinite {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+
+			a = append(a, v)
+		}
+
+		return a, nil
+	case majorMap:
+		o := types.NewObject()
+		for i := uint64(0); indefinite || i < arg; i++ {
+			key, err := d.key(depth)
+			if err == errBreak && indefinite {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+
+			v, err := d.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+
+			o.Set(key, v)
+		}
+
+		return o, nil
+	case majorTag:
+		return d.tagged(ib, arg, depth)
+	default:
+		if indefinite {
+			return nil, errBreak
+		}
+
+		switch ib & 0x1f {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22, 23:
+			return nil, nil
+		case 25:
+			return halfToFloat(uint16(arg)), nil
+		case 26:
+			return float64(math.Float32frombits(uint32(arg))), nil
+		case 27:
+			return math.Float64frombits(arg), nil
+		default:
+			return nil, &InvalidItemError{Initial: ib, Reason: "unsupported simple value"}
+		}
+	}
+}
+
+// halfToFloat converts an IEEE 754 half-precision float.
+func halfToFloat(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+
+	exp, frac := int(h>>10&0x1f), float64(h&0x3ff)
+
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac != 0 {
+			return math.NaN()
+		}
+
+		return math.Inf(int(sign))
+	default:
+		return sign * math.Ldexp(frac+1024, exp-25)
+	}
+}
+
+// decode reads every item in a sequence (RFC 8742).
+func decode(r io.Reader) ([]interface{}, error) {
+	d := &decoder{r: bufio.NewReader(r)}
+
+	var out []interface{}
+	for {
+		if _, err := d.r.Peek(1); err == io.EOF {
+			return out, nil
+		}
+
+		v, err := d.value(0)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		out = append(out, v)
+	}
+}
+
+// FromCBOR decodes a sequence of CBOR items, producing one output for each.
+func FromCBOR(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := types.ReaderOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := decode(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing CBOR")
+	}
+
+	out := make([]context.Valuer, len(values))
+	for i, value := range values {
+		out[i] = context.NewConstValuer(value)
+	}
+
+	return out, nil
+}
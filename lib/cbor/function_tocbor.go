@@ -0,0 +1,177 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+type encoder struct {
+	b bytes.Buffer
+}
+
+// head writes the initial byte of an item and its argument using the
+// shortest encoding.
+func (e *encoder) head(major byte, arg uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], arg)
+
+	switch {
+	case arg < 24:
+		e.b.WriteByte(major<<5 | byte(arg))
+	case arg <= math.MaxUint8:
+		e.b.WriteByte(major<<5 | 24)
+		e.b.Write(b[7:])
+	case arg <= math.MaxUint16:
+		e.b.WriteByte(major<<5 | 25)
+		e.b.Write(b[6:])
+	case arg <= math.MaxUint32:
+		e.b.WriteByte(major<<5 | 26)
+		e.b.Write(b[4:])
+	default:
+		e.b.WriteByte(major<<5 | 27)
+		e.b.Write(b[:])
+	}
+}
+
+func (e *encoder) int(i int64) {
+	if i >= 0 {
+		e.head(majorUint, uint64(i))
+	} else {
+		e.head(majorNegInt, uint64(-1-i))
+	}
+}
+
+func (e *encoder) float(f float64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], math.Float64bits(f))
+
+	e.b.WriteByte(majorSimple<<5 | 27)
+	e.b.Write(b[:])
+}
+
+func (e *encoder) text(s string) {
+	e.head(majorText, uint64(len(s)))
+	e.b.WriteString(s)
+}
+
+func (e *encoder) value(ctx *context.Context, v interface{}) error {
+	switch vt := ctx.Convert(v).(type) {
+	case nil:
+		e.b.WriteByte(0xf6)
+	case bool:
+		if vt {
+			e.b.WriteByte(0xf5)
+		} else {
+			e.b.WriteByte(0xf4)
+		}
+	case types.Int:
+		e.int(int64(vt))
+	case types.Float:
+		e.float(float64(vt))
+	case types.Number:
+		r, ok := vt.Rat()
+		if !ok || !r.IsInt() || r.Num().IsInt64() {
+			return e.value(ctx, vt.Demote())
+		}
+
+		// Integers beyond int64 fit in a head if their magnitude fits
+		// in a uint64, and are bignums otherwise.
+		n := r.Num()
+		if n.Sign() > 0 {
+			if n.IsUint64() {
+				e.head(majorUint, n.Uint64())
+			} else {
+				e.head(majorTag, tagBignum)
+				e.head(majorBytes, uint64(len(n.Bytes())))
+				e.b.Write(n.Bytes())
+			}
+		} else {
+			m := new(big.Int).Neg(n)
+			m.Sub(m, big.NewInt(1))
+
+			if m.IsUint64() {
+				e.head(majorNegInt, m.Uint64())
+			} else {
+				e.head(majorTag, tagNegBignum)
+				e.head(majorBytes, uint64(len(m.Bytes())))
+				e.b.Write(m.Bytes())
+			}
+		}
+	case types.Str:
+		e.text(string(vt))
+	case types.Bytes:
+		e.head(majorBytes, uint64(len(vt)))
+		e.b.Write(vt)
+	case types.Time:
+		// Date/time strings keep the time zone offset.
+		e.head(majorTag, tagDateTime)
+		e.text(vt.Time.Format(time.RFC3339Nano))
+	case types.Array:
+		e.head(majorArray, uint64(len(vt)))
+		for _, item := range vt {
+			if err := e.value(ctx, item); err != nil {
+				return err
+			}
+		}
+	case *types.Object:
+		e.head(majorMap, uint64(vt.Len()))
+		for _, key := range vt.Keys() {
+			e.text(key)
+
+			value, _ := vt.Get(key)
+			if err := e.value(ctx, value); err != nil {
+				return err
+			}
+		}
+	default:
+		// Anything else is encoded the same way as in JSON.
+		b, err := json.Marshal(vt)
+		if err != nil {
+			return err
+		}
+
+		var jv interface{}
+		if err := json.Unmarshal(b, &jv); err != nil {
+			return err
+		}
+
+		return e.value(ctx, jv)
+	}
+
+	return nil
+}
+
+type toCBOR struct {
+	v interface{}
+}
+
+func (tc *toCBOR) Value(ctx *context.Context) (interface{}, error) {
+	v := tc.v
+	if sortKeys, _ := ctx.Option(types.OptionSortKeys).(bool); sortKeys {
+		v = types.SortKeys(v)
+	}
+
+	var e encoder
+	if err := e.value(ctx, v); err != nil {
+		return nil, errors.Wrap(err, "marshaling CBOR")
+	}
+
+	return types.Bytes(e.b.Bytes()), nil
+}
+
+func ToCBOR(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{&toCBOR{v}}, nil
+}
@@ -0,0 +1,32 @@
+// Package compress provides compression and decompression of bytes.
+// Compression uses the default level of each format. Only decompression is
+// supported for bzip2.
+package compress
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(Bunzip2)
+	ctx.DefineFunction("bunzip2", fn)
+
+	fn, _ = function.NewFunction(Deflate)
+	ctx.DefineFunction("deflate", fn)
+
+	fn, _ = function.NewFunction(Gunzip)
+	ctx.DefineFunction("gunzip", fn)
+
+	fn, _ = function.NewFunction(Gzip)
+	ctx.DefineFunction("gzip", fn)
+
+	fn, _ = function.NewFunction(Inflate)
+	ctx.DefineFunction("inflate", fn)
+
+	fn, _ = function.NewFunction(ZlibDecode)
+	ctx.DefineFunction("zlibdecode", fn)
+
+	fn, _ = function.NewFunction(ZlibEncode)
+	ctx.DefineFunction("zlibencode", fn)
+}
@@ -0,0 +1,60 @@
+package compress
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type transform func(ctx *context.Context, in context.Valuer) ([]context.Valuer, error)
+
+func apply(ctx *context.Context, fn transform, in interface{}) (interface{}, error) {
+	vrs, err := fn(ctx, context.NewConstValuer(in))
+	if err != nil {
+		return nil, err
+	}
+
+	return vrs[0].Value(ctx)
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	in := types.Str("hello hello hello hello")
+
+	pairs := []struct {
+		Compress, Decompress transform
+	}{
+		{Gzip, Gunzip},
+		{Deflate, Inflate},
+		{ZlibEncode, ZlibDecode},
+	}
+
+	for _, pair := range pairs {
+		compressed, err := apply(ctx, pair.Compress, in)
+		assert.NoError(t, err)
+		assert.NotEqual(t, types.Bytes(in), compressed)
+
+		out, err := apply(ctx, pair.Decompress, compressed)
+		assert.NoError(t, err)
+		assert.Equal(t, types.Bytes(in), out)
+	}
+
+	_, err := apply(ctx, Gunzip, in)
+	assert.Error(t, err)
+}
+
+func TestBunzip2(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	b, _ := hex.DecodeString("425a6839314159265359c1c080e2000001410000100244a00030cd00c3462997177245385090c1c080e2")
+
+	out, err := apply(ctx, Bunzip2, types.Bytes(b))
+	assert.NoError(t, err)
+	assert.Equal(t, types.Bytes("hello\n"), out)
+}
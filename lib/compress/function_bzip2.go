@@ -0,0 +1,14 @@
+package compress
+
+import (
+	"compress/bzip2"
+	"io"
+
+	"github.com/reflect/filq/context"
+)
+
+func Bunzip2(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return decompressWith(ctx, in, "bzip2", func(r io.Reader) (io.Reader, error) {
+		return bzip2.NewReader(r), nil
+	})
+}
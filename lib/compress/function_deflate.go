@@ -0,0 +1,23 @@
+package compress
+
+import (
+	"compress/flate"
+	"io"
+
+	"github.com/reflect/filq/context"
+)
+
+// Inflate decompresses raw DEFLATE data, without a zlib or gzip header.
+func Inflate(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return decompressWith(ctx, in, "DEFLATE", func(r io.Reader) (io.Reader, error) {
+		return flate.NewReader(r), nil
+	})
+}
+
+// Deflate compresses data as raw DEFLATE, without a zlib or gzip header.
+func Deflate(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return compressWith(ctx, in, "DEFLATE", func(w io.Writer) io.WriteCloser {
+		fw, _ := flate.NewWriter(w, flate.DefaultCompression)
+		return fw
+	})
+}
@@ -0,0 +1,20 @@
+package compress
+
+import (
+	"compress/gzip"
+	"io"
+
+	"github.com/reflect/filq/context"
+)
+
+func Gunzip(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return decompressWith(ctx, in, "gzip", func(r io.Reader) (io.Reader, error) {
+		return gzip.NewReader(r)
+	})
+}
+
+func Gzip(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return compressWith(ctx, in, "gzip", func(w io.Writer) io.WriteCloser {
+		return gzip.NewWriter(w)
+	})
+}
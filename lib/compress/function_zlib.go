@@ -0,0 +1,20 @@
+package compress
+
+import (
+	"compress/zlib"
+	"io"
+
+	"github.com/reflect/filq/context"
+)
+
+func ZlibDecode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return decompressWith(ctx, in, "zlib", func(r io.Reader) (io.Reader, error) {
+		return zlib.NewReader(r)
+	})
+}
+
+func ZlibEncode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return compressWith(ctx, in, "zlib", func(w io.Writer) io.WriteCloser {
+		return zlib.NewWriter(w)
+	})
+}
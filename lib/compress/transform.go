@@ -0,0 +1,84 @@
+package compress
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+type readerFunc func(r io.Reader) (io.Reader, error)
+
+type writerFunc func(w io.Writer) io.WriteCloser
+
+type decompress struct {
+	in     []byte
+	name   string
+	reader readerFunc
+}
+
+func (d *decompress) Value(ctx *context.Context) (interface{}, error) {
+	r, err := d.reader(bytes.NewReader(d.in))
+	if err != nil {
+		return nil, errors.Wrapf(err, "decompressing %s", d.name)
+	}
+
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decompressing %s", d.name)
+	}
+
+	return types.Bytes(b), nil
+}
+
+type compress struct {
+	in     []byte
+	name   string
+	writer writerFunc
+}
+
+func (c *compress) Value(ctx *context.Context) (interface{}, error) {
+	var b bytes.Buffer
+
+	w := c.writer(&b)
+	if _, err := w.Write(c.in); err != nil {
+		return nil, errors.Wrapf(err, "compressing %s", c.name)
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, errors.Wrapf(err, "compressing %s", c.name)
+	}
+
+	return types.Bytes(b.Bytes()), nil
+}
+
+func decompressWith(ctx *context.Context, in context.Valuer, name string, reader readerFunc) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := types.BytesOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{&decompress{in: b, name: name, reader: reader}}, nil
+}
+
+func compressWith(ctx *context.Context, in context.Valuer, name string, writer writerFunc) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := types.BytesOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{&compress{in: b, name: name, writer: writer}}, nil
+}
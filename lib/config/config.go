@@ -0,0 +1,39 @@
+// Package config converts between filq values and the flat-ish formats used
+// for configuration files: TOML, INI, Java .properties and dotenv.
+//
+// TOML and INI documents decode to nested objects. Properties and dotenv files
+// decode to flat objects of strings, because their keys do not reliably
+// describe a hierarchy. Decoded objects keep the order of the keys in the
+// document.
+package config
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(FromDotenv)
+	ctx.DefineFunction("fromdotenv", fn)
+
+	fn, _ = function.NewFunction(FromINI)
+	ctx.DefineFunction("fromini", fn)
+
+	fn, _ = function.NewFunction(FromProperties)
+	ctx.DefineFunction("fromproperties", fn)
+
+	fn, _ = function.NewFunction(FromTOML)
+	ctx.DefineFunction("fromtoml", fn)
+
+	fn, _ = function.NewFunction(ToDotenv)
+	ctx.DefineFunction("todotenv", fn)
+
+	fn, _ = function.NewFunction(ToINI)
+	ctx.DefineFunction("toini", fn)
+
+	fn, _ = function.NewFunction(ToProperties)
+	ctx.DefineFunction("toproperties", fn)
+
+	fn, _ = function.NewFunction(ToTOML)
+	ctx.DefineFunction("totoml", fn)
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	conds := []struct {
+		Name     string
+		Decode   decodeFunc
+		Encode   encodeFunc
+		In       string
+		Expected string
+	}{
+		{
+			"TOML", decodeTOML, encodeTOML,
+			"z = 1\na = 2.5\ninline = { y = true, x = \"s\" }\n\n[t]\nk = [1, 2]\n\n[[arr]]\nn = \"a\"\n\n[[arr]]\nn = \"b\"\n",
+			`{"z":1,"a":2.5,"inline":{"y":true,"x":"s"},"t":{"k":[1,2]},"arr":[{"n":"a"},{"n":"b"}]}`,
+		},
+		{
+			"INI", decodeINI, encodeINI,
+			"; comment\ntop = 1\n\n[db]\nhost = \" x\"\nflag\n",
+			`{"top":"1","db":{"host":" x","flag":null}}`,
+		},
+		{
+			"properties", decodeProperties, encodeProperties,
+			"# comment\na.b=1\nkey\\ with\\:sep=v\\u00e9 \\\n    cont\n",
+			`{"a.b":"1","key with:sep":"vé cont"}`,
+		},
+		{
+			"dotenv", decodeDotenv, encodeDotenv,
+			"export A=1 # comment\nB=\"two\\nlines \\$x\"\nC='lit \\n'\nD=\n",
+			`{"A":"1","B":"two\nlines $x","C":"lit \\n","D":""}`,
+		},
+	}
+
+	for _, cond := range conds {
+		v, err := cond.Decode(cond.In)
+		assert.NoError(t, err, cond.Name)
+
+		b, err := json.Marshal(v)
+		assert.NoError(t, err, cond.Name)
+		assert.Equal(t, cond.Expected, string(b), cond.Name)
+
+		out, err := cond.Encode(ctx, v.(*types.Object))
+		assert.NoError(t, err, cond.Name)
+
+		again, err := cond.Decode(out)
+		assert.NoError(t, err, cond.Name)
+
+		b, err = json.Marshal(again)
+		assert.NoError(t, err, cond.Name)
+		assert.Equal(t, cond.Expected, string(b), cond.Name)
+	}
+}
+
+func TestTOMLLocalTimes(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	in := "ldt = 1979-05-27T07:32:00.5\nld = 1979-05-27\nlt = 07:32:00\nodt = 1979-05-27T07:32:00-07:00\n"
+
+	v, err := decodeTOML(in)
+	assert.NoError(t, err)
+
+	out, err := encodeTOML(ctx, ctx.Convert(v).(*types.Object))
+	assert.NoError(t, err)
+	assert.Equal(t, in, out)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	conds := []struct {
+		Decode decodeFunc
+		In     string
+	}{
+		{decodeTOML, "a = "},
+		{decodeINI, "[section"},
+		{decodeProperties, "a=\\u12"},
+		{decodeDotenv, "A=\"unterminated"},
+		{decodeDotenv, "no separator"},
+	}
+
+	for _, cond := range conds {
+		_, err := cond.Decode(cond.In)
+		assert.Error(t, err, cond.In)
+	}
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func objectOf(v interface{}) (*types.Object, error) {
+	o, ok := v.(*types.Object)
+	if !ok {
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(&types.Object{}),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	return o, nil
+}
+
+// lines splits a document into lines, accepting any line ending.
+func lines(s string) []string {
+	s = strings.Replace(s, "\r\n", "\n", -1)
+	s = strings.Replace(s, "\r", "\n", -1)
+	return strings.Split(s, "\n")
+}
+
+type decodeFunc func(s string) (interface{}, error)
+
+// fromText applies a decoder to a string or bytes input.
+func fromText(ctx *context.Context, in context.Valuer, decode decodeFunc) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	lazy := func(ctx *context.Context) (interface{}, error) {
+		out, err := decode(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return ctx.Convert(out), nil
+	}
+
+	return []context.Valuer{context.NewLazyValuer(lazy)}, nil
+}
+
+type encodeFunc func(ctx *context.Context, o *types.Object) (string, error)
+
+type toText struct {
+	o      *types.Object
+	encode encodeFunc
+}
+
+func (tt *toText) Value(ctx *context.Context) (interface{}, error) {
+	o := tt.o
+	if sortKeys, _ := ctx.Option(types.OptionSortKeys).(bool); sortKeys {
+		o = types.SortKeys(o).(*types.Object)
+	}
+
+	s, err := tt.encode(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.Bytes(s), nil
+}
+
+// toTextOf applies an encoder to an object input.
+func toTextOf(ctx *context.Context, in context.Valuer, encode encodeFunc) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := objectOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{&toText{o: o, encode: encode}}, nil
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"fmt"
+)
+
+type ParseError struct {
+	Format string
+	Line   int
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s parse error on line %d: %s", e.Format, e.Line, e.Reason)
+}
+
+type UnsupportedValueError struct {
+	Format, Key, Reason string
+}
+
+func (e *UnsupportedValueError) Error() string {
+	return fmt.Sprintf("cannot encode %q as %s: %s", e.Key, e.Format, e.Reason)
+}
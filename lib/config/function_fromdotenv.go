@@ -0,0 +1,132 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// dotenvValue reads a value from the start of s, returning it and the rest of
+// the input after the line it ends on. Single-quoted values are literal,
+// double-quoted values support backslash escapes and both may span lines.
+// Unquoted values end at the end of the line or at a comment.
+func dotenvValue(s string) (string, string, int, error) {
+	if s == "" || (s[0] != '"' && s[0] != '\'') {
+		line := s
+		rest := ""
+		if i := strings.IndexByte(s, '\n'); i >= 0 {
+			line, rest = s[:i], s[i+1:]
+		}
+
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		} else if i := strings.Index(line, "\t#"); i >= 0 {
+			line = line[:i]
+		}
+
+		return strings.TrimSpace(line), rest, 1, nil
+	}
+
+	quote := s[0]
+	newlines := 0
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == quote:
+			rest := s[i+1:]
+
+			// Anything after the closing quote must be a comment.
+			line := rest
+			if j := strings.IndexByte(rest, '\n'); j >= 0 {
+				line, rest = rest[:j], rest[j+1:]
+			} else {
+				rest = ""
+			}
+
+			if line = strings.TrimSpace(line); line != "" && line[0] != '#' {
+				return "", "", 0, errors.Errorf("unexpected %q after quoted value", line)
+			}
+
+			return b.String(), rest, newlines + 1, nil
+		case c == '\\' && quote == '"' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(s[i])
+			}
+		default:
+			if c == '\n' {
+				newlines++
+			}
+
+			b.WriteByte(c)
+		}
+	}
+
+	return "", "", 0, errors.New("unterminated quoted value")
+}
+
+// decodeDotenv reads a dotenv file into a flat object of strings. Lines may
+// start with "export". Variables are not expanded.
+func decodeDotenv(s string) (interface{}, error) {
+	o := types.NewObject()
+
+	s = strings.Replace(s, "\r\n", "\n", -1)
+	line := 1
+
+	for s != "" {
+		cur := s
+		rest := ""
+		if i := strings.IndexByte(s, '\n'); i >= 0 {
+			cur, rest = s[:i], s[i+1:]
+		}
+
+		trimmed := strings.TrimSpace(cur)
+		if trimmed == "" || trimmed[0] == '#' {
+			s = rest
+			line++
+			continue
+		}
+
+		trimmed = strings.TrimPrefix(trimmed, "export ")
+
+		eq := strings.IndexByte(trimmed, '=')
+		if eq < 0 {
+			return nil, errors.WithStack(&ParseError{Format: "dotenv", Line: line, Reason: "missing \"=\""})
+		}
+
+		key := strings.TrimSpace(trimmed[:eq])
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, errors.WithStack(&ParseError{Format: "dotenv", Line: line, Reason: "invalid key"})
+		}
+
+		// Values are read from the original input, since quoted values
+		// may continue on later lines.
+		start := strings.Index(s, "=") + 1
+		value, next, n, err := dotenvValue(strings.TrimLeft(s[start:], " \t"))
+		if err != nil {
+			return nil, errors.WithStack(&ParseError{Format: "dotenv", Line: line, Reason: err.Error()})
+		}
+
+		o.Set(key, value)
+
+		s = next
+		line += n
+	}
+
+	return o, nil
+}
+
+func FromDotenv(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return fromText(ctx, in, decodeDotenv)
+}
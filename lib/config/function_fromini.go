@@ -0,0 +1,72 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// unquote removes matching single or double quotes around a value.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
+
+// decodeINI reads an INI file. Keys before the first section are stored at
+// the top level and keys in a section in an object named after it. Values
+// are strings, except for keys without a value, which are null. Lines
+// starting with ";" or "#" are comments.
+func decodeINI(s string) (interface{}, error) {
+	root := types.NewObject()
+	section := root
+
+	for i, line := range lines(s) {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, errors.WithStack(&ParseError{Format: "INI", Line: i + 1, Reason: "unterminated section header"})
+			}
+
+			name := strings.TrimSpace(line[1 : len(line)-1])
+
+			if existing, ok := root.Get(name); ok {
+				if so, ok := existing.(*types.Object); ok {
+					section = so
+					continue
+				}
+			}
+
+			section = types.NewObject()
+			root.Set(name, section)
+			continue
+		}
+
+		sep := strings.IndexAny(line, "=:")
+		if sep < 0 {
+			section.Set(line, nil)
+			continue
+		}
+
+		key := strings.TrimSpace(line[:sep])
+		if key == "" {
+			return nil, errors.WithStack(&ParseError{Format: "INI", Line: i + 1, Reason: "empty key"})
+		}
+
+		section.Set(key, unquote(strings.TrimSpace(line[sep+1:])))
+	}
+
+	return root, nil
+}
+
+func FromINI(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return fromText(ctx, in, decodeINI)
+}
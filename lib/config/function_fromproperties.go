@@ -0,0 +1,140 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// logicalLines joins lines that end in an odd number of backslashes with the
+// following line, dropping the leading whitespace of the continuation. Each
+// logical line is returned with the number of its first physical line.
+func logicalLines(s string) ([]string, []int) {
+	var out []string
+	var numbers []int
+
+	var cur strings.Builder
+	continued := false
+
+	for i, line := range lines(s) {
+		if continued {
+			line = strings.TrimLeft(line, " \t\f")
+		} else {
+			trimmed := strings.TrimLeft(line, " \t\f")
+			if trimmed == "" || trimmed[0] == '#' || trimmed[0] == '!' {
+				continue
+			}
+
+			line = trimmed
+			numbers = append(numbers, i+1)
+		}
+
+		slashes := len(line) - len(strings.TrimRight(line, `\`))
+		continued = slashes%2 == 1
+		if continued {
+			line = line[:len(line)-1]
+		}
+
+		cur.WriteString(line)
+		if !continued {
+			out = append(out, cur.String())
+			cur.Reset()
+		}
+	}
+
+	if continued {
+		out = append(out, cur.String())
+	}
+
+	return out, numbers
+}
+
+// unescapeProperties reads an escaped string up to the first unescaped
+// character for which stop returns true, returning the unescaped text and
+// the remainder of the input.
+func unescapeProperties(s string, stop func(c byte) bool) (string, string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if stop != nil && stop(c) {
+			return b.String(), s[i:], nil
+		}
+
+		if c != '\\' || i+1 == len(s) {
+			b.WriteByte(c)
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+5 > len(s) {
+				return "", "", errors.New("truncated \\u escape")
+			}
+
+			r, err := strconv.ParseUint(s[i+1:i+5], 16, 16)
+			if err != nil {
+				return "", "", errors.Errorf("invalid \\u escape %q", s[i+1:i+5])
+			}
+
+			b.WriteRune(rune(r))
+			i += 4
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String(), "", nil
+}
+
+func isPropertiesSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\f'
+}
+
+// decodeProperties reads a Java .properties file into a flat object of
+// strings.
+func decodeProperties(s string) (interface{}, error) {
+	o := types.NewObject()
+
+	logical, numbers := logicalLines(s)
+	for i, line := range logical {
+		key, rest, err := unescapeProperties(line, func(c byte) bool {
+			return c == '=' || c == ':' || isPropertiesSpace(c)
+		})
+		if err != nil {
+			return nil, errors.WithStack(&ParseError{Format: "properties", Line: numbers[i], Reason: err.Error()})
+		}
+
+		// The separator is either whitespace, "=" or ":", optionally
+		// surrounded by whitespace.
+		rest = strings.TrimLeft(rest, " \t\f")
+		if rest != "" && (rest[0] == '=' || rest[0] == ':') {
+			rest = strings.TrimLeft(rest[1:], " \t\f")
+		}
+
+		value, _, err := unescapeProperties(rest, nil)
+		if err != nil {
+			return nil, errors.WithStack(&ParseError{Format: "properties", Line: numbers[i], Reason: err.Error()})
+		}
+
+		o.Set(key, value)
+	}
+
+	return o, nil
+}
+
+func FromProperties(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return fromText(ctx, in, decodeProperties)
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// Local date-times, dates and times have no offset. They are decoded as times
+// in these zones, which record their kind so that they are encoded the same
+// way, and which have a zero offset so that the decoded values do not depend
+// on the local time zone.
+var (
+	tomlLocalDatetime = time.FixedZone("datetime-local", 0)
+	tomlLocalDate     = time.FixedZone("date-local", 0)
+	tomlLocalTime     = time.FixedZone("time-local", 0)
+)
+
+// tomlLocal returns the zone for a local value decoded in the given zone, or
+// nil if the value has an offset.
+func tomlLocal(loc *time.Location) *time.Location {
+	for _, local := range []*time.Location{tomlLocalDatetime, tomlLocalDate, tomlLocalTime} {
+		if loc.String() == local.String() {
+			return local
+		}
+	}
+
+	return nil
+}
+
+// tomlOrder records, for each table path, the order in which its keys first
+// appear in the document. Tables in an array share a path.
+type tomlOrder map[string][]string
+
+func newTOMLOrder(keys []toml.Key) tomlOrder {
+	order := make(tomlOrder)
+	seen := make(map[string]bool)
+
+	for _, key := range keys {
+		parent := strings.Join(key[:len(key)-1], "\x00")
+		path := strings.Join(key, "\x00")
+
+		if !seen[path] {
+			seen[path] = true
+			order[parent] = append(order[parent], key[len(key)-1])
+		}
+	}
+
+	return order
+}
+
+func (to tomlOrder) convert(path []string, v interface{}) interface{} {
+	switch vt := v.(type) {
+	case map[string]interface{}:
+		o := types.NewObject()
+
+		// Keys of inline tables are not reported by the decoder, so any
+		// remaining keys are added in sorted order.
+		for _, key := range to[strings.Join(path, "\x00")] {
+			if value, ok := vt[key]; ok {
+				o.Set(key, to.convert(append(path, key), value))
+			}
+		}
+
+		rest := types.NewObjectFromMap(vt)
+		for _, key := range rest.Keys() {
+			if _, ok := o.Get(key); !ok {
+				o.Set(key, to.convert(append(path, key), vt[key]))
+			}
+		}
+
+		return o
+	case []map[string]interface{}:
+		a := make([]interface{}, len(vt))
+		for i, table := range vt {
+			a[i] = to.convert(path, table)
+		}
+
+		return a
+	case []interface{}:
+		a := make([]interface{}, len(vt))
+		for i, item := range vt {
+			a[i] = to.convert(path, item)
+		}
+
+		return a
+	case time.Time:
+		if local := tomlLocal(vt.Location()); local != nil {
+			return time.Date(vt.Year(), vt.Month(), vt.Day(), vt.Hour(), vt.Minute(), vt.Second(), vt.Nanosecond(), local)
+		}
+
+		return vt
+	default:
+		return vt
+	}
+}
+
+func decodeTOML(s string) (interface{}, error) {
+	var m map[string]interface{}
+
+	md, err := toml.Decode(s, &m)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing TOML")
+	}
+
+	return newTOMLOrder(md.Keys()).convert(nil, m), nil
+}
+
+func FromTOML(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return fromText(ctx, in, decodeTOML)
+}
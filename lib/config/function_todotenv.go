@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+var (
+	dotenvKey  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+	dotenvBare = regexp.MustCompile(`^[A-Za-z0-9_./:@,+-]*$`)
+
+	dotenvEscaper = strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		`$`, `\$`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	)
+)
+
+// encodeDotenv writes a flat object as a dotenv file, quoting values that
+// contain anything other than common punctuation.
+func encodeDotenv(ctx *context.Context, o *types.Object) (string, error) {
+	var b strings.Builder
+
+	for _, key := range o.Keys() {
+		if !dotenvKey.MatchString(key) {
+			return "", errors.WithStack(&UnsupportedValueError{Format: "dotenv", Key: key, Reason: "invalid variable name"})
+		}
+
+		value, _ := o.Get(key)
+
+		switch ctx.Convert(value).(type) {
+		case types.Array, *types.Object:
+			return "", errors.WithStack(&UnsupportedValueError{Format: "dotenv", Key: key, Reason: "values must be scalars"})
+		}
+
+		s, err := types.TextOf(ctx.Convert(value))
+		if err != nil {
+			return "", err
+		}
+
+		if !dotenvBare.MatchString(s) {
+			s = `"` + dotenvEscaper.Replace(s) + `"`
+		}
+
+		fmt.Fprintf(&b, "%s=%s\n", key, s)
+	}
+
+	return b.String(), nil
+}
+
+func ToDotenv(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return toTextOf(ctx, in, encodeDotenv)
+}
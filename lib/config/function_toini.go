@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func iniValue(ctx *context.Context, section, key string, v interface{}) (string, error) {
+	if _, ok := ctx.Convert(v).(*types.Object); ok {
+		return "", errors.WithStack(&UnsupportedValueError{Format: "INI", Key: key, Reason: fmt.Sprintf("section %q cannot be nested", section)})
+	}
+
+	s, err := types.TextOf(ctx.Convert(v))
+	if err != nil {
+		return "", err
+	}
+
+	if strings.ContainsAny(s, "\r\n") {
+		return "", errors.WithStack(&UnsupportedValueError{Format: "INI", Key: key, Reason: "value spans multiple lines"})
+	}
+
+	// Quote values that would otherwise lose surrounding whitespace or
+	// quotes.
+	if s != strings.TrimSpace(s) || unquote(s) != s {
+		s = `"` + s + `"`
+	}
+
+	return s, nil
+}
+
+// writeINIKey writes a single key. Keys with null values are written without
+// a value.
+func writeINIKey(ctx *context.Context, b *strings.Builder, section, key string, v interface{}) error {
+	if ctx.Convert(v) == nil {
+		fmt.Fprintf(b, "%s\n", key)
+		return nil
+	}
+
+	s, err := iniValue(ctx, section, key, v)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(b, "%s = %s\n", key, s)
+	return nil
+}
+
+// encodeINI writes the scalar keys of an object followed by a section for
+// each object value.
+func encodeINI(ctx *context.Context, o *types.Object) (string, error) {
+	var b strings.Builder
+
+	for _, key := range o.Keys() {
+		value, _ := o.Get(key)
+		if _, ok := ctx.Convert(value).(*types.Object); ok {
+			continue
+		}
+
+		if err := writeINIKey(ctx, &b, "", key, value); err != nil {
+			return "", err
+		}
+	}
+
+	for _, key := range o.Keys() {
+		value, _ := o.Get(key)
+
+		section, ok := ctx.Convert(value).(*types.Object)
+		if !ok {
+			continue
+		}
+
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "[%s]\n", key)
+
+		for _, sk := range section.Keys() {
+			sv, _ := section.Get(sk)
+
+			if err := writeINIKey(ctx, &b, key, sk, sv); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return b.String(), nil
+}
+
+func ToINI(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return toTextOf(ctx, in, encodeINI)
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// escapeProperties escapes a key or value. Keys also need their separators
+// escaped, and values any leading space, which would otherwise be dropped.
+func escapeProperties(s string, key bool) string {
+	var b strings.Builder
+
+	for i, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			if key {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		case ' ':
+			if key || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// writeProperties writes the keys of an object, flattening nested objects
+// into dotted keys.
+func writeProperties(ctx *context.Context, b *strings.Builder, prefix string, o *types.Object) error {
+	for _, key := range o.Keys() {
+		value, _ := o.Get(key)
+
+		if so, ok := ctx.Convert(value).(*types.Object); ok {
+			if err := writeProperties(ctx, b, prefix+key+".", so); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		s, err := types.TextOf(ctx.Convert(value))
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(b, "%s=%s\n", escapeProperties(prefix+key, true), escapeProperties(s, false))
+	}
+
+	return nil
+}
+
+func encodeProperties(ctx *context.Context, o *types.Object) (string, error) {
+	var b strings.Builder
+	if err := writeProperties(ctx, &b, "", o); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func ToProperties(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return toTextOf(ctx, in, encodeProperties)
+}
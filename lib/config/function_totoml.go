@@ -0,0 +1,222 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+var tomlBareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func tomlKey(key string) string {
+	if tomlBareKey.MatchString(key) {
+		return key
+	}
+
+	return tomlString(key)
+}
+
+func tomlString(s string) string {
+	var b strings.Builder
+
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+
+	return b.String()
+}
+
+func isTable(ctx *context.Context, v interface{}) bool {
+	_, ok := ctx.Convert(v).(*types.Object)
+	return ok
+}
+
+// isTableArray determines whether a value is a non-empty array of objects,
+// which is written as an array of tables.
+func isTableArray(ctx *context.Context, v interface{}) bool {
+	a, ok := ctx.Convert(v).(types.Array)
+	if !ok || len(a) == 0 {
+		return false
+	}
+
+	for _, item := range a {
+		if !isTable(ctx, item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// tomlValue formats a value inline. Objects become inline tables.
+func tomlValue(ctx *context.Context, key string, v interface{}) (string, error) {
+	switch vt := ctx.Convert(v).(type) {
+	case nil:
+		return "", errors.WithStack(&UnsupportedValueError{Format: "TOML", Key: key, Reason: "TOML has no null"})
+	case bool:
+		return strconv.FormatBool(vt), nil
+	case types.Int:
+		return strconv.FormatInt(int64(vt), 10), nil
+	case types.Float:
+		f := float64(vt)
+		switch {
+		case math.IsNaN(f):
+			return "nan", nil
+		case math.IsInf(f, 1):
+			return "inf", nil
+		case math.IsInf(f, -1):
+			return "-inf", nil
+		case f == math.Trunc(f) && math.Abs(f) < 1e16:
+			// Keep the value a float when it is read back.
+			return strconv.FormatFloat(f, 'f', 1, 64), nil
+		default:
+			return strconv.FormatFloat(f, 'g', -1, 64), nil
+		}
+	case types.Number:
+		return string(vt), nil
+	case types.Str:
+		return tomlString(string(vt)), nil
+	case types.Bytes:
+		return tomlString(string(vt)), nil
+	case types.Time:
+		switch tomlLocal(vt.Location()) {
+		case tomlLocalDatetime:
+			return vt.Format("2006-01-02T15:04:05.999999999"), nil
+		case tomlLocalDate:
+			return vt.Format("2006-01-02"), nil
+		case tomlLocalTime:
+			return vt.Format("15:04:05.999999999"), nil
+		default:
+			return vt.Time.Format(time.RFC3339Nano), nil
+		}
+	case types.Array:
+		items := make([]string, len(vt))
+		for i, item := range vt {
+			s, err := tomlValue(ctx, key, item)
+			if err != nil {
+				return "", err
+			}
+
+			items[i] = s
+		}
+
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case *types.Object:
+		var items []string
+		for _, k := range vt.Keys() {
+			value, _ := vt.Get(k)
+			if ctx.Convert(value) == nil {
+				continue
+			}
+
+			s, err := tomlValue(ctx, k, value)
+			if err != nil {
+				return "", err
+			}
+
+			items = append(items, tomlKey(k)+" = "+s)
+		}
+
+		return "{" + strings.Join(items, ", ") + "}", nil
+	default:
+		s, err := types.TextOf(ctx.Convert(vt))
+		if err != nil {
+			return "", err
+		}
+
+		return tomlString(s), nil
+	}
+}
+
+// encodeTOMLTable writes the key/value pairs of a table followed by its
+// subtables. Keys with null values are omitted.
+func encodeTOMLTable(ctx *context.Context, b *strings.Builder, path []string, o *types.Object) error {
+	for _, key := range o.Keys() {
+		value, _ := o.Get(key)
+		if ctx.Convert(value) == nil || isTable(ctx, value) || isTableArray(ctx, value) {
+			continue
+		}
+
+		s, err := tomlValue(ctx, key, value)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(b, "%s = %s\n", tomlKey(key), s)
+	}
+
+	for _, key := range o.Keys() {
+		value, _ := o.Get(key)
+
+		sub := make([]string, len(path)+1)
+		copy(sub, path)
+		sub[len(path)] = tomlKey(key)
+
+		if isTable(ctx, value) {
+			if b.Len() > 0 {
+				b.WriteByte('\n')
+			}
+			fmt.Fprintf(b, "[%s]\n", strings.Join(sub, "."))
+
+			if err := encodeTOMLTable(ctx, b, sub, ctx.Convert(value).(*types.Object)); err != nil {
+				return err
+			}
+		} else if isTableArray(ctx, value) {
+			for _, item := range ctx.Convert(value).(types.Array) {
+				if b.Len() > 0 {
+					b.WriteByte('\n')
+				}
+				fmt.Fprintf(b, "[[%s]]\n", strings.Join(sub, "."))
+
+				if err := encodeTOMLTable(ctx, b, sub, ctx.Convert(item).(*types.Object)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func encodeTOML(ctx *context.Context, o *types.Object) (string, error) {
+	var b strings.Builder
+	if err := encodeTOMLTable(ctx, &b, nil, o); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func ToTOML(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return toTextOf(ctx, in, encodeTOML)
+}
@@ -0,0 +1,89 @@
+package csv
+
+import (
+	"reflect"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// config describes the dialect of a delimited file. A zero Comment disables
+// comments.
+type config struct {
+	Delimiter, Quote, Comment rune
+	Header                    bool
+}
+
+var (
+	csvConfig = config{Delimiter: ',', Quote: '"'}
+	tsvConfig = config{Delimiter: '\t', Quote: '"'}
+)
+
+// configOf overrides the settings in the given base configuration with those
+// in an object. The recognized keys are delimiter, quote, comment (each a
+// single character, or an empty string to disable comments) and header.
+func configOf(v interface{}, base config) (config, error) {
+	o, ok := v.(*types.Object)
+	if !ok {
+		return base, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(&types.Object{}),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	c := base
+	for _, key := range o.Keys() {
+		value, _ := o.Get(key)
+
+		switch key {
+		case "delimiter", "quote", "comment":
+			s, err := types.StringOf(value)
+			if err != nil {
+				return c, err
+			}
+
+			var r rune
+			switch utf8.RuneCountInString(s) {
+			case 0:
+				if key != "comment" {
+					return c, errors.WithStack(&InvalidConfigError{Key: key, Reason: "must not be empty"})
+				}
+			case 1:
+				r, _ = utf8.DecodeRuneInString(s)
+				if r == '\r' || r == '\n' {
+					return c, errors.WithStack(&InvalidConfigError{Key: key, Reason: "must not be a line break"})
+				}
+			default:
+				return c, errors.WithStack(&InvalidConfigError{Key: key, Reason: "must be a single character"})
+			}
+
+			switch key {
+			case "delimiter":
+				c.Delimiter = r
+			case "quote":
+				c.Quote = r
+			case "comment":
+				c.Comment = r
+			}
+		case "header":
+			b, ok := value.(bool)
+			if !ok {
+				return c, errors.WithStack(&InvalidConfigError{Key: key, Reason: "must be a boolean"})
+			}
+
+			c.Header = b
+		default:
+			return c, errors.WithStack(&InvalidConfigError{Key: key, Reason: "unknown key"})
+		}
+	}
+
+	if c.Delimiter == c.Quote || c.Delimiter == c.Comment || c.Quote == c.Comment {
+		return c, errors.WithStack(&InvalidConfigError{Key: "delimiter", Reason: "delimiter, quote and comment must differ"})
+	}
+
+	return c, nil
+}
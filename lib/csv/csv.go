@@ -0,0 +1,35 @@
+package csv
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(FromCSV)
+	ctx.DefineFunction("fromcsv", fn)
+
+	fn, _ = function.NewFunction(FromCSVWithConfig)
+	ctx.DefineFunction("fromcsv", fn)
+
+	fn, _ = function.NewFunction(FromFixed)
+	ctx.DefineFunction("fromfixed", fn)
+
+	fn, _ = function.NewFunction(FromTSV)
+	ctx.DefineFunction("fromtsv", fn)
+
+	fn, _ = function.NewFunction(FromTSVWithConfig)
+	ctx.DefineFunction("fromtsv", fn)
+
+	fn, _ = function.NewFunction(ToCSV)
+	ctx.DefineFunction("tocsv", fn)
+
+	fn, _ = function.NewFunction(ToCSVWithConfig)
+	ctx.DefineFunction("tocsv", fn)
+
+	fn, _ = function.NewFunction(ToTSV)
+	ctx.DefineFunction("totsv", fn)
+
+	fn, _ = function.NewFunction(ToTSVWithConfig)
+	ctx.DefineFunction("totsv", fn)
+}
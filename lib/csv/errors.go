@@ -0,0 +1,47 @@
+package csv
+
+import (
+	"fmt"
+)
+
+type InvalidConfigError struct {
+	Key, Reason string
+}
+
+func (e *InvalidConfigError) Error() string {
+	return fmt.Sprintf("invalid value for configuration key %q: %s", e.Key, e.Reason)
+}
+
+type ParseError struct {
+	Line   int
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse error on line %d: %s", e.Line, e.Reason)
+}
+
+type FieldCountError struct {
+	Line, Wanted, Got int
+}
+
+func (e *FieldCountError) Error() string {
+	return fmt.Sprintf("record on line %d has %d fields (wanted at most %d)", e.Line, e.Got, e.Wanted)
+}
+
+type DuplicateHeaderError struct {
+	Name string
+}
+
+func (e *DuplicateHeaderError) Error() string {
+	return fmt.Sprintf("header has more than one column named %q", e.Name)
+}
+
+type InvalidColumnSpecError struct {
+	Index  int
+	Reason string
+}
+
+func (e *InvalidColumnSpecError) Error() string {
+	return fmt.Sprintf("invalid column %d in spec: %s", e.Index, e.Reason)
+}
@@ -0,0 +1,122 @@
+package csv
+
+import (
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+type fromDelimited struct {
+	c  config
+	in string
+}
+
+func (fd *fromDelimited) Value(ctx *context.Context) (interface{}, error) {
+	r := newReader(fd.c, fd.in)
+
+	var header []string
+	if fd.c.Header {
+		record, _, err := r.readRecord()
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing header")
+		}
+
+		seen := make(map[string]bool, len(record))
+		for _, name := range record {
+			if seen[name] {
+				return nil, errors.WithStack(&DuplicateHeaderError{Name: name})
+			}
+
+			seen[name] = true
+		}
+
+		header = record
+	}
+
+	rows := []interface{}{}
+	for {
+		record, line, err := r.readRecord()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		} else if record == nil {
+			break
+		}
+
+		if header == nil {
+			row := make([]interface{}, len(record))
+			for i, field := range record {
+				row[i] = types.Str(field)
+			}
+
+			rows = append(rows, row)
+			continue
+		}
+
+		if len(record) > len(header) {
+			return nil, errors.WithStack(&FieldCountError{Line: line, Wanted: len(header), Got: len(record)})
+		}
+
+		// Missing trailing fields are null.
+		row := types.NewObject()
+		for i, name := range header {
+			if i < len(record) {
+				row.Set(name, types.Str(record[i]))
+			} else {
+				row.Set(name, nil)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return ctx.Convert(rows), nil
+}
+
+func fromDelimitedWithConfig(ctx *context.Context, in context.Valuer, base config, configs []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if configs == nil {
+		return []context.Valuer{&fromDelimited{c: base, in: s}}, nil
+	}
+
+	out := make([]context.Valuer, len(configs))
+	for i, cfg := range configs {
+		cv, err := cfg.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := configOf(cv, base)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = &fromDelimited{c: c, in: s}
+	}
+
+	return out, nil
+}
+
+func FromCSV(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return fromDelimitedWithConfig(ctx, in, csvConfig, nil)
+}
+
+func FromCSVWithConfig(ctx *context.Context, in context.Valuer, configs []context.Valuer) ([]context.Valuer, error) {
+	return fromDelimitedWithConfig(ctx, in, csvConfig, configs)
+}
+
+func FromTSV(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return fromDelimitedWithConfig(ctx, in, tsvConfig, nil)
+}
+
+func FromTSVWithConfig(ctx *context.Context, in context.Valuer, configs []context.Valuer) ([]context.Valuer, error) {
+	return fromDelimitedWithConfig(ctx, in, tsvConfig, configs)
+}
@@ -0,0 +1,176 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+type column struct {
+	name         string
+	start, width int
+}
+
+func intOf(v interface{}) (int, bool) {
+	switch vt := v.(type) {
+	case types.Int:
+		return int(vt), true
+	case types.Float:
+		if vt == types.Float(int(vt)) {
+			return int(vt), true
+		}
+	case types.Number:
+		return intOf(vt.Demote())
+	}
+
+	return 0, false
+}
+
+// columnsOf reads a column spec. Each column is either a width, or an object
+// with a name, a width and an optional start offset. Columns without a start
+// offset begin where the previous column ends. Either all columns or none of
+// them must have names.
+func columnsOf(ctx *context.Context, v interface{}) ([]column, bool, error) {
+	a, ok := v.(types.Array)
+	if !ok {
+		return nil, false, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(types.Array{}),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	columns := make([]column, len(a))
+	named := false
+
+	next := 0
+	for i, cv := range a {
+		c := column{start: next}
+
+		switch cvt := ctx.Convert(cv).(type) {
+		case *types.Object:
+			if i > 0 && !named {
+				return nil, false, errors.WithStack(&InvalidColumnSpecError{Index: i, Reason: "cannot mix named and unnamed columns"})
+			}
+
+			named = true
+
+			nv, _ := cvt.Get("name")
+			name, err := types.StringOf(ctx.Convert(nv))
+			if err != nil {
+				return nil, false, errors.WithStack(&InvalidColumnSpecError{Index: i, Reason: "name must be a string"})
+			}
+
+			c.name = name
+
+			wv, _ := cvt.Get("width")
+			if c.width, ok = intOf(ctx.Convert(wv)); !ok {
+				return nil, false, errors.WithStack(&InvalidColumnSpecError{Index: i, Reason: "width must be an integer"})
+			}
+
+			if sv, ok := cvt.Get("start"); ok {
+				if c.start, ok = intOf(ctx.Convert(sv)); !ok || c.start < 0 {
+					return nil, false, errors.WithStack(&InvalidColumnSpecError{Index: i, Reason: "start must be a non-negative integer"})
+				}
+			}
+		default:
+			if named {
+				return nil, false, errors.WithStack(&InvalidColumnSpecError{Index: i, Reason: "cannot mix named and unnamed columns"})
+			}
+
+			if c.width, ok = intOf(cvt); !ok {
+				return nil, false, errors.WithStack(&InvalidColumnSpecError{Index: i, Reason: "width must be an integer"})
+			}
+		}
+
+		if c.width <= 0 {
+			return nil, false, errors.WithStack(&InvalidColumnSpecError{Index: i, Reason: "width must be positive"})
+		}
+
+		columns[i] = c
+		next = c.start + c.width
+	}
+
+	return columns, named, nil
+}
+
+type fromFixed struct {
+	columns []column
+	named   bool
+	in      string
+}
+
+func (ff *fromFixed) Value(ctx *context.Context) (interface{}, error) {
+	rows := []interface{}{}
+
+	for _, line := range strings.Split(ff.in, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		runes := []rune(line)
+		field := func(c column) types.Str {
+			start, end := c.start, c.start+c.width
+			if start > len(runes) {
+				start = len(runes)
+			}
+			if end > len(runes) {
+				end = len(runes)
+			}
+
+			return types.Str(strings.TrimSpace(string(runes[start:end])))
+		}
+
+		if ff.named {
+			row := types.NewObject()
+			for _, c := range ff.columns {
+				row.Set(c.name, field(c))
+			}
+
+			rows = append(rows, row)
+		} else {
+			row := make([]interface{}, len(ff.columns))
+			for i, c := range ff.columns {
+				row[i] = field(c)
+			}
+
+			rows = append(rows, row)
+		}
+	}
+
+	return ctx.Convert(rows), nil
+}
+
+func FromFixed(ctx *context.Context, in context.Valuer, specs []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]context.Valuer, len(specs))
+	for i, spec := range specs {
+		sv, err := spec.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		columns, named, err := columnsOf(ctx, sv)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = &fromFixed{columns: columns, named: named, in: s}
+	}
+
+	return out, nil
+}
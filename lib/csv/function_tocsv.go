@@ -0,0 +1,154 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+type toDelimited struct {
+	c  config
+	in types.Array
+}
+
+func (td *toDelimited) Value(ctx *context.Context) (interface{}, error) {
+	rows := make([]interface{}, len(td.in))
+	objects := false
+
+	for i, row := range td.in {
+		rows[i] = ctx.Convert(row)
+
+		switch rows[i].(type) {
+		case types.Array:
+		case *types.Object:
+			objects = true
+		default:
+			return nil, errors.WithStack(&context.UnexpectedTypeError{
+				Wanted: []reflect.Type{
+					reflect.TypeOf(types.Array{}),
+					reflect.TypeOf(&types.Object{}),
+				},
+				Got: reflect.TypeOf(rows[i]),
+			})
+		}
+	}
+
+	var b strings.Builder
+
+	// The columns for rows of objects are the union of their keys, in the
+	// order they are first seen.
+	var header []string
+	if objects {
+		seen := make(map[string]bool)
+		for _, row := range rows {
+			o, ok := row.(*types.Object)
+			if !ok {
+				return nil, errors.WithStack(&context.UnexpectedTypeError{
+					Wanted: []reflect.Type{reflect.TypeOf(&types.Object{})},
+					Got:    reflect.TypeOf(row),
+				})
+			}
+
+			for _, key := range o.Keys() {
+				if !seen[key] {
+					seen[key] = true
+					header = append(header, key)
+				}
+			}
+		}
+
+		if td.c.Header {
+			writeRecord(&b, td.c, header)
+		}
+	}
+
+	for _, row := range rows {
+		var values []interface{}
+
+		switch rt := row.(type) {
+		case types.Array:
+			values = rt
+		case *types.Object:
+			values = make([]interface{}, len(header))
+			for i, key := range header {
+				values[i], _ = rt.Get(key)
+			}
+		}
+
+		record := make([]string, len(values))
+		for i, value := range values {
+			field, err := types.TextOf(ctx.Convert(value))
+			if err != nil {
+				return nil, err
+			}
+
+			record[i] = field
+		}
+
+		writeRecord(&b, td.c, record)
+	}
+
+	return types.Str(b.String()), nil
+}
+
+// toDelimitedWithConfig formats an array of rows, each either an array of
+// fields or an object. Rows of objects are preceded by a header unless the
+// configuration disables it.
+func toDelimitedWithConfig(ctx *context.Context, in context.Valuer, base config, configs []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	a, ok := v.(types.Array)
+	if !ok {
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(types.Array{}),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	base.Header = true
+
+	if configs == nil {
+		return []context.Valuer{&toDelimited{c: base, in: a}}, nil
+	}
+
+	out := make([]context.Valuer, len(configs))
+	for i, cfg := range configs {
+		cv, err := cfg.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := configOf(cv, base)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = &toDelimited{c: c, in: a}
+	}
+
+	return out, nil
+}
+
+func ToCSV(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return toDelimitedWithConfig(ctx, in, csvConfig, nil)
+}
+
+func ToCSVWithConfig(ctx *context.Context, in context.Valuer, configs []context.Valuer) ([]context.Valuer, error) {
+	return toDelimitedWithConfig(ctx, in, csvConfig, configs)
+}
+
+func ToTSV(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return toDelimitedWithConfig(ctx, in, tsvConfig, nil)
+}
+
+func ToTSVWithConfig(ctx *context.Context, in context.Valuer, configs []context.Valuer) ([]context.Valuer, error) {
+	return toDelimitedWithConfig(ctx, in, tsvConfig, configs)
+}
@@ -0,0 +1,145 @@
+package csv
+
+import (
+	"strings"
+)
+
+// reader splits delimited text into records. Unlike encoding/csv, the quote
+// character is configurable.
+type reader struct {
+	c    config
+	in   []rune
+	pos  int
+	line int
+}
+
+func newReader(c config, in string) *reader {
+	return &reader{c: c, in: []rune(in), line: 1}
+}
+
+func (r *reader) peek() (rune, bool) {
+	if r.pos >= len(r.in) {
+		return 0, false
+	}
+
+	return r.in[r.pos], true
+}
+
+// endOfLine consumes a line break at the current position, if there is one.
+func (r *reader) endOfLine() bool {
+	c, ok := r.peek()
+	switch {
+	case !ok:
+		return true
+	case c == '\n':
+		r.pos++
+	case c == '\r':
+		r.pos++
+		if c, ok := r.peek(); ok && c == '\n' {
+			r.pos++
+		}
+	default:
+		return false
+	}
+
+	r.line++
+	return true
+}
+
+// skipLine consumes everything up to and including the next line break.
+func (r *reader) skipLine() {
+	for !r.endOfLine() {
+		r.pos++
+	}
+}
+
+// readRecord returns the next record and the line it started on. Comments are
+// skipped. Without a header, a blank line is a record with a single empty
+// field; with one, blank lines are skipped, since they cannot be a row. At the
+// end of the input, it returns nil.
+func (r *reader) readRecord() ([]string, int, error) {
+	for {
+		c, ok := r.peek()
+		if !ok {
+			return nil, r.line, nil
+		}
+
+		if r.c.Comment != 0 && c == r.c.Comment {
+			r.skipLine()
+			continue
+		}
+
+		if r.c.Header && (c == '\n' || c == '\r') {
+			r.endOfLine()
+			continue
+		}
+
+		break
+	}
+
+	start := r.line
+
+	var record []string
+	for {
+		field, err := r.readField()
+		if err != nil {
+			return nil, start, err
+		}
+
+		record = append(record, field)
+
+		if c, ok := r.peek(); ok && c == r.c.Delimiter {
+			r.pos++
+			continue
+		}
+
+		if !r.endOfLine() {
+			return nil, start, &ParseError{Line: r.line, Reason: "unexpected character after quoted field"}
+		}
+
+		return record, start, nil
+	}
+}
+
+func (r *reader) readField() (string, error) {
+	var b strings.Builder
+
+	if c, ok := r.peek(); !ok || c != r.c.Quote {
+		for {
+			c, ok := r.peek()
+			if !ok || c == r.c.Delimiter || c == '\n' || c == '\r' {
+				return b.String(), nil
+			}
+
+			b.WriteRune(c)
+			r.pos++
+		}
+	}
+
+	// Skip the opening quote.
+	r.pos++
+	line := r.line
+
+	for {
+		c, ok := r.peek()
+		if !ok {
+			return "", &ParseError{Line: line, Reason: "unterminated quoted field"}
+		}
+
+		r.pos++
+
+		if c == r.c.Quote {
+			// A doubled quote is a literal quote; anything else ends the
+			// field.
+			if next, ok := r.peek(); ok && next == r.c.Quote {
+				r.pos++
+			} else {
+				return b.String(), nil
+			}
+		} else if c == '\n' {
+			r.line++
+		}
+
+		b.WriteRune(c)
+	}
+}
@@ -0,0 +1,136 @@
+package csv
+
+import (
+	"encoding/json"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func readAll(c config, in string) ([][]string, error) {
+	r := newReader(c, in)
+
+	var records [][]string
+	for {
+		record, _, err := r.readRecord()
+		if err != nil {
+			return nil, err
+		} else if record == nil {
+			return records, nil
+		}
+
+		records = append(records, record)
+	}
+}
+
+func TestReader(t *testing.T) {
+	conds := []struct {
+		Config   config
+		In       string
+		Expected [][]string
+	}{
+		{csvConfig, "a,b\r\n1,\"2,3\"\n", [][]string{{"a", "b"}, {"1", "2,3"}}},
+		{csvConfig, "\"x\"\"y\",\"multi\nline\"", [][]string{{`x"y`, "multi\nline"}}},
+		{csvConfig, "a,,\n\n,b", [][]string{{"a", "", ""}, {""}, {"", "b"}}},
+		{csvConfig, "a\n\"\"\n\n", [][]string{{"a"}, {""}, {""}}},
+		{tsvConfig, "a\tb,c\n", [][]string{{"a", "b,c"}}},
+		{
+			config{Delimiter: ';', Quote: '\'', Comment: '#'},
+			"# note\n'a;b';'it''s'\n",
+			[][]string{{"a;b", "it's"}},
+		},
+	}
+
+	for _, cond := range conds {
+		records, err := readAll(cond.Config, cond.In)
+		assert.NoError(t, err, cond.In)
+		assert.Equal(t, cond.Expected, records, cond.In)
+	}
+
+	for _, invalid := range []string{"\"abc", "\"a\"b,c"} {
+		_, err := readAll(csvConfig, invalid)
+		assert.IsType(t, &ParseError{}, errors.Cause(err), invalid)
+	}
+}
+
+func TestWriteRecordRoundTrip(t *testing.T) {
+	c := config{Delimiter: ';', Quote: '\'', Comment: '#'}
+	record := []string{"plain", "a;b", "it's", " padded", "#hash", "two\nlines", ""}
+
+	var b strings.Builder
+	writeRecord(&b, c, record)
+
+	records, err := readAll(c, b.String())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{record}, records)
+}
+
+func TestEmptyRecordRoundTrip(t *testing.T) {
+	in := [][]string{{"a"}, {""}, {"b"}}
+
+	var b strings.Builder
+	for _, record := range in {
+		writeRecord(&b, csvConfig, record)
+	}
+	assert.Equal(t, "a\n\"\"\nb\n", b.String())
+
+	records, err := readAll(csvConfig, b.String())
+	assert.NoError(t, err)
+	assert.Equal(t, in, records)
+}
+
+func TestHeader(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	c := csvConfig
+	c.Header = true
+
+	// Blank lines are not rows, but a quoted empty field is.
+	conds := []struct {
+		In, Expected string
+	}{
+		{"a,b\n1,2\n\n", `[{"a":"1","b":"2"}]`},
+		{"\na,b\r\n\r\n1,2\n\n3\n", `[{"a":"1","b":"2"},{"a":"3","b":null}]`},
+		{"a,b\n\"\"\n", `[{"a":"","b":null}]`},
+	}
+
+	for _, cond := range conds {
+		v, err := (&fromDelimited{c: c, in: cond.In}).Value(ctx)
+		assert.NoError(t, err, cond.In)
+
+		b, err := json.Marshal(v)
+		assert.NoError(t, err, cond.In)
+		assert.Equal(t, cond.Expected, string(b), cond.In)
+	}
+
+	_, err := (&fromDelimited{c: c, in: "a,b,a\n1,2,3\n"}).Value(ctx)
+	assert.Equal(t, &DuplicateHeaderError{Name: "a"}, errors.Cause(err))
+}
+
+func TestToCSVScalars(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	// Values that marshal as JSON strings are written without the quotes.
+	row := types.Array{
+		types.Time{Time: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)},
+		types.IP{Addr: netip.MustParseAddr("10.0.0.1")},
+		types.Float(1.5),
+		nil,
+		types.Array{types.Int(1), types.Str("a,b")},
+	}
+
+	vrs, err := ToCSV(ctx, context.NewConstValuer(types.Array{row}))
+	assert.NoError(t, err)
+
+	v, err := vrs[0].Value(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Str("2024-01-02T03:04:05Z,10.0.0.1,1.5,,\"[1,\"\"a,b\"\"]\"\n"), v)
+}
@@ -0,0 +1,58 @@
+package csv
+
+import (
+	"strings"
+)
+
+// writeRecord appends a record to the builder, quoting fields only when they
+// contain the delimiter, the quote character, a line break or leading
+// whitespace. Fields that start with the comment character are also quoted so
+// that they survive a round trip, and so is a record that is a single empty
+// field, which would otherwise be a blank line.
+func writeRecord(b *strings.Builder, c config, record []string) {
+	if len(record) == 1 && record[0] == "" {
+		b.WriteRune(c.Quote)
+		b.WriteRune(c.Quote)
+		b.WriteByte('\n')
+		return
+	}
+
+	for i, field := range record {
+		if i > 0 {
+			b.WriteRune(c.Delimiter)
+		}
+
+		if !needsQuotes(c, field) {
+			b.WriteString(field)
+			continue
+		}
+
+		b.WriteRune(c.Quote)
+		for _, r := range field {
+			if r == c.Quote {
+				b.WriteRune(c.Quote)
+			}
+
+			b.WriteRune(r)
+		}
+		b.WriteRune(c.Quote)
+	}
+
+	b.WriteByte('\n')
+}
+
+func needsQuotes(c config, field string) bool {
+	if field == "" {
+		return false
+	}
+
+	if field[0] == ' ' || field[0] == '\t' {
+		return true
+	}
+
+	if c.Comment != 0 && strings.HasPrefix(field, string(c.Comment)) {
+		return true
+	}
+
+	return strings.ContainsAny(field, string([]rune{c.Delimiter, c.Quote, '\r', '\n'}))
+}
@@ -0,0 +1,80 @@
+package encoding
+
+import (
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+type codec struct {
+	encode func(b []byte) string
+	decode func(s string) ([]byte, error)
+}
+
+// codecsOf looks up the codecs for the given variant names.
+func codecsOf(ctx *context.Context, encoding string, variants map[string]codec, names []context.Valuer) ([]codec, error) {
+	codecs := make([]codec, len(names))
+	for i, name := range names {
+		nv, err := name.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := types.BytesOf(nv)
+		if err != nil {
+			return nil, err
+		}
+
+		c, ok := variants[string(b)]
+		if !ok {
+			return nil, errors.WithStack(&UnknownVariantError{Encoding: encoding, Variant: string(b)})
+		}
+
+		codecs[i] = c
+	}
+
+	return codecs, nil
+}
+
+func encode(ctx *context.Context, in context.Valuer, codecs []codec) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := types.BytesOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]context.Valuer, len(codecs))
+	for i, c := range codecs {
+		out[i] = context.NewConstValuer(types.Str(c.encode(b)))
+	}
+
+	return out, nil
+}
+
+func decode(ctx *context.Context, in context.Valuer, codecs []codec) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := types.BytesOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]context.Valuer, len(codecs))
+	for i, c := range codecs {
+		d, err := c.decode(string(b))
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding")
+		}
+
+		out[i] = context.NewConstValuer(types.Bytes(d))
+	}
+
+	return out, nil
+}
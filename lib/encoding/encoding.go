@@ -0,0 +1,46 @@
+// Package encoding provides binary-to-text encodings. Encoders accept strings
+// or bytes and return strings; decoders return bytes.
+package encoding
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(ASCII85Decode)
+	ctx.DefineFunction("ascii85decode", fn)
+
+	fn, _ = function.NewFunction(ASCII85Encode)
+	ctx.DefineFunction("ascii85encode", fn)
+
+	fn, _ = function.NewFunction(Base32Decode)
+	ctx.DefineFunction("base32decode", fn)
+
+	fn, _ = function.NewFunction(Base32DecodeWithVariant)
+	ctx.DefineFunction("base32decode", fn)
+
+	fn, _ = function.NewFunction(Base32Encode)
+	ctx.DefineFunction("base32encode", fn)
+
+	fn, _ = function.NewFunction(Base32EncodeWithVariant)
+	ctx.DefineFunction("base32encode", fn)
+
+	fn, _ = function.NewFunction(Base64Decode)
+	ctx.DefineFunction("base64decode", fn)
+
+	fn, _ = function.NewFunction(Base64DecodeWithVariant)
+	ctx.DefineFunction("base64decode", fn)
+
+	fn, _ = function.NewFunction(Base64Encode)
+	ctx.DefineFunction("base64encode", fn)
+
+	fn, _ = function.NewFunction(Base64EncodeWithVariant)
+	ctx.DefineFunction("base64encode", fn)
+
+	fn, _ = function.NewFunction(HexDecode)
+	ctx.DefineFunction("hexdecode", fn)
+
+	fn, _ = function.NewFunction(HexEncode)
+	ctx.DefineFunction("hexencode", fn)
+}
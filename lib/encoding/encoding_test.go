@@ -0,0 +1,58 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodecs(t *testing.T) {
+	in := []byte("hi?>\xff")
+
+	conds := []struct {
+		Codec   codec
+		Encoded string
+	}{
+		{base64Variants["std"], "aGk/Pv8="},
+		{base64Variants["url"], "aGk_Pv8="},
+		{base64Variants["rawstd"], "aGk/Pv8"},
+		{base64Variants["rawurl"], "aGk_Pv8"},
+		{base32Variants["std"], "NBUT6PX7"},
+		{base32Variants["hex"], "D1KJUFNV"},
+		{hexCodec, "68693f3eff"},
+		{ascii85Codec, "BPB[prr"},
+	}
+
+	for _, cond := range conds {
+		assert.Equal(t, cond.Encoded, cond.Codec.encode(in))
+
+		out, err := cond.Codec.decode(cond.Encoded)
+		assert.NoError(t, err, cond.Encoded)
+		assert.Equal(t, in, out, cond.Encoded)
+	}
+
+	out, err := ascii85Decode("<~BPB[prr~>")
+	assert.NoError(t, err)
+	assert.Equal(t, in, out)
+}
+
+func TestVariants(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	vrs, err := Base64DecodeWithVariant(ctx, context.NewConstValuer("aGk_Pv8"), []context.Valuer{context.NewConstValuer("rawurl")})
+	assert.NoError(t, err)
+
+	v, err := vrs[0].Value(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, types.Bytes("hi?>\xff"), v)
+
+	_, err = Base64EncodeWithVariant(ctx, context.NewConstValuer("x"), []context.Valuer{context.NewConstValuer("nope")})
+	assert.IsType(t, &UnknownVariantError{}, errors.Cause(err))
+
+	_, err = HexDecode(ctx, context.NewConstValuer("zz"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,13 @@
+package encoding
+
+import (
+	"fmt"
+)
+
+type UnknownVariantError struct {
+	Encoding, Variant string
+}
+
+func (e *UnknownVariantError) Error() string {
+	return fmt.Sprintf("unknown %s variant %q", e.Encoding, e.Variant)
+}
@@ -0,0 +1,42 @@
+package encoding
+
+import (
+	"encoding/ascii85"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+)
+
+func ascii85Encode(b []byte) string {
+	out := make([]byte, ascii85.MaxEncodedLen(len(b)))
+	return string(out[:ascii85.Encode(out, b)])
+}
+
+// ascii85Decode decodes Ascii85, ignoring the "<~" and "~>" delimiters used by
+// Adobe if they are present.
+func ascii85Decode(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "<~") && strings.HasSuffix(s, "~>") {
+		s = s[2 : len(s)-2]
+	}
+
+	out := make([]byte, 4*len(s))
+
+	n, _, err := ascii85.Decode(out, []byte(s), true)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return out[:n], nil
+}
+
+var ascii85Codec = codec{ascii85Encode, ascii85Decode}
+
+func ASCII85Encode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return encode(ctx, in, []codec{ascii85Codec})
+}
+
+func ASCII85Decode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return decode(ctx, in, []codec{ascii85Codec})
+}
@@ -0,0 +1,47 @@
+package encoding
+
+import (
+	"encoding/base32"
+
+	"github.com/reflect/filq/context"
+)
+
+var (
+	rawStdBase32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+	rawHexBase32 = base32.HexEncoding.WithPadding(base32.NoPadding)
+)
+
+// base32Variants are the alphabets (RFC 4648 standard and extended hex) and
+// padding modes of base32 by name.
+var base32Variants = map[string]codec{
+	"std":    {base32.StdEncoding.EncodeToString, base32.StdEncoding.DecodeString},
+	"hex":    {base32.HexEncoding.EncodeToString, base32.HexEncoding.DecodeString},
+	"rawstd": {rawStdBase32.EncodeToString, rawStdBase32.DecodeString},
+	"rawhex": {rawHexBase32.EncodeToString, rawHexBase32.DecodeString},
+}
+
+func Base32Encode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return encode(ctx, in, []codec{base32Variants["std"]})
+}
+
+func Base32EncodeWithVariant(ctx *context.Context, in context.Valuer, variants []context.Valuer) ([]context.Valuer, error) {
+	codecs, err := codecsOf(ctx, "base32", base32Variants, variants)
+	if err != nil {
+		return nil, err
+	}
+
+	return encode(ctx, in, codecs)
+}
+
+func Base32Decode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return decode(ctx, in, []codec{base32Variants["std"]})
+}
+
+func Base32DecodeWithVariant(ctx *context.Context, in context.Valuer, variants []context.Valuer) ([]context.Valuer, error) {
+	codecs, err := codecsOf(ctx, "base32", base32Variants, variants)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode(ctx, in, codecs)
+}
@@ -0,0 +1,41 @@
+package encoding
+
+import (
+	"encoding/base64"
+
+	"github.com/reflect/filq/context"
+)
+
+// base64Variants are the alphabets and padding modes of base64 by name.
+var base64Variants = map[string]codec{
+	"std":    {base64.StdEncoding.EncodeToString, base64.StdEncoding.DecodeString},
+	"url":    {base64.URLEncoding.EncodeToString, base64.URLEncoding.DecodeString},
+	"rawstd": {base64.RawStdEncoding.EncodeToString, base64.RawStdEncoding.DecodeString},
+	"rawurl": {base64.RawURLEncoding.EncodeToString, base64.RawURLEncoding.DecodeString},
+}
+
+func Base64Encode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return encode(ctx, in, []codec{base64Variants["std"]})
+}
+
+func Base64EncodeWithVariant(ctx *context.Context, in context.Valuer, variants []context.Valuer) ([]context.Valuer, error) {
+	codecs, err := codecsOf(ctx, "base64", base64Variants, variants)
+	if err != nil {
+		return nil, err
+	}
+
+	return encode(ctx, in, codecs)
+}
+
+func Base64Decode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return decode(ctx, in, []codec{base64Variants["std"]})
+}
+
+func Base64DecodeWithVariant(ctx *context.Context, in context.Valuer, variants []context.Valuer) ([]context.Valuer, error) {
+	codecs, err := codecsOf(ctx, "base64", base64Variants, variants)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode(ctx, in, codecs)
+}
@@ -0,0 +1,17 @@
+package encoding
+
+import (
+	"encoding/hex"
+
+	"github.com/reflect/filq/context"
+)
+
+var hexCodec = codec{hex.EncodeToString, hex.DecodeString}
+
+func HexEncode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return encode(ctx, in, []codec{hexCodec})
+}
+
+func HexDecode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return decode(ctx, in, []codec{hexCodec})
+}
@@ -0,0 +1,100 @@
+package hash
+
+import (
+	"encoding/hex"
+	gohash "hash"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// stringsOf evaluates each valuer to a string.
+func stringsOf(ctx *context.Context, vrs []context.Valuer) ([]string, error) {
+	out := make([]string, len(vrs))
+	for i, vr := range vrs {
+		v, err := vr.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := types.BytesOf(v)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = string(b)
+	}
+
+	return out, nil
+}
+
+func algorithmOf(name string) (func() gohash.Hash, error) {
+	fn, ok := algorithms[name]
+	if !ok {
+		return nil, errors.WithStack(&UnknownAlgorithmError{Algorithm: name})
+	}
+
+	return fn, nil
+}
+
+func formatDigest(sum []byte, format string) (interface{}, error) {
+	switch format {
+	case "hex":
+		return types.Str(hex.EncodeToString(sum)), nil
+	case "raw":
+		return types.Bytes(sum), nil
+	default:
+		return nil, errors.WithStack(&UnknownFormatError{Format: format})
+	}
+}
+
+func digest(ctx *context.Context, in context.Valuer, name string, formats []string) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := types.BytesOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	newHash, err := algorithmOf(name)
+	if err != nil {
+		return nil, err
+	}
+
+	h := newHash()
+	h.Write(b)
+	sum := h.Sum(nil)
+
+	out := make([]context.Valuer, len(formats))
+	for i, format := range formats {
+		d, err := formatDigest(sum, format)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = context.NewConstValuer(d)
+	}
+
+	return out, nil
+}
+
+func newDigest(name string) func(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return func(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+		return digest(ctx, in, name, []string{"hex"})
+	}
+}
+
+func newDigestWithFormat(name string) func(ctx *context.Context, in context.Valuer, formats []context.Valuer) ([]context.Valuer, error) {
+	return func(ctx *context.Context, in context.Valuer, formats []context.Valuer) ([]context.Valuer, error) {
+		fs, err := stringsOf(ctx, formats)
+		if err != nil {
+			return nil, err
+		}
+
+		return digest(ctx, in, name, fs)
+	}
+}
@@ -0,0 +1,21 @@
+package hash
+
+import (
+	"fmt"
+)
+
+type UnknownAlgorithmError struct {
+	Algorithm string
+}
+
+func (e *UnknownAlgorithmError) Error() string {
+	return fmt.Sprintf("unknown hash algorithm %q", e.Algorithm)
+}
+
+type UnknownFormatError struct {
+	Format string
+}
+
+func (e *UnknownFormatError) Error() string {
+	return fmt.Sprintf("unknown digest format %q (wanted hex or raw)", e.Format)
+}
@@ -0,0 +1,127 @@
+package hash
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// bytesKey tags bytes that are not valid UTF-8 in the canonical form. Object
+// keys that start with "$" are escaped with another "$", so a tagged value
+// never collides with an object from the input.
+const bytesKey = "$bytes"
+
+// canonical converts a value so that values that are structurally equal have
+// the same JSON representation: object keys are sorted, numbers are written
+// as exact decimals, and bytes are written as strings rather than base64.
+// Bytes that are not valid UTF-8 would be mangled as strings, so they are
+// written as {"$bytes": base64} instead. Times are written in UTC, IPv4-mapped
+// addresses as IPv4 and the build metadata of versions is dropped, so that
+// equal values hash alike. Non-finite numbers become null, as in JSON.
+func canonical(ctx *context.Context, v interface{}) interface{} {
+	switch vt := ctx.Convert(v).(type) {
+	case *types.Object:
+		so := vt.Sorted()
+
+		o := types.NewObject()
+		for _, key := range so.Keys() {
+			value, _ := so.Get(key)
+			if strings.HasPrefix(key, "$") {
+				key = "$" + key
+			}
+
+			o.Set(key, canonical(ctx, value))
+		}
+
+		return o
+	case types.Array:
+		a := make([]interface{}, len(vt))
+		for i, item := range vt {
+			a[i] = canonical(ctx, item)
+		}
+
+		return a
+	case types.Int, types.Float, types.Number:
+		r, ok := types.ToRat(vt)
+		if !ok {
+			return nil
+		}
+
+		return types.NewRatNumber(r)
+	case types.Bytes:
+		if !utf8.Valid(vt) {
+			o := types.NewObject()
+			o.Set(bytesKey, base64.StdEncoding.EncodeToString(vt))
+
+			return o
+		}
+
+		return types.Str(vt)
+	case types.Time:
+		return types.Str(vt.UTC().Format(time.RFC3339Nano))
+	case types.Semver:
+		vt.Build = nil
+		return types.Str(vt.String())
+	case types.Duration:
+		return types.Str(vt.String())
+	case types.IP:
+		return types.Str(vt.Unmap().String())
+	default:
+		return vt
+	}
+}
+
+// canonicalJSON returns the canonical JSON representation of a value.
+func canonicalJSON(ctx *context.Context, v interface{}) ([]byte, error) {
+	b, err := json.Marshal(canonical(ctx, v))
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling canonical JSON")
+	}
+
+	return b, nil
+}
+
+func hashWithAlgorithm(ctx *context.Context, in context.Valuer, names []string) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := canonicalJSON(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, name := range names {
+		vrs, err := digest(ctx, context.NewConstValuer(types.Bytes(b)), name, []string{"hex"})
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, vrs...)
+	}
+
+	return out, nil
+}
+
+// Hash returns the SHA-256 digest of the canonical JSON representation of any
+// value.
+func Hash(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return hashWithAlgorithm(ctx, in, []string{"sha256"})
+}
+
+func HashWithAlgorithm(ctx *context.Context, in context.Valuer, algs []context.Valuer) ([]context.Valuer, error) {
+	names, err := stringsOf(ctx, algs)
+	if err != nil {
+		return nil, err
+	}
+
+	return hashWithAlgorithm(ctx, in, names)
+}
@@ -0,0 +1,68 @@
+package hash
+
+import (
+	"crypto/hmac"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func hmacWithFormat(ctx *context.Context, in context.Valuer, algs, keys []context.Valuer, formats []string) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := types.BytesOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := stringsOf(ctx, algs)
+	if err != nil {
+		return nil, err
+	}
+
+	ks, err := stringsOf(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, name := range names {
+		newHash, err := algorithmOf(name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range ks {
+			mac := hmac.New(newHash, []byte(key))
+			mac.Write(b)
+			sum := mac.Sum(nil)
+
+			for _, format := range formats {
+				d, err := formatDigest(sum, format)
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, context.NewConstValuer(d))
+			}
+		}
+	}
+
+	return out, nil
+}
+
+func HMAC(ctx *context.Context, in context.Valuer, algs, keys []context.Valuer) ([]context.Valuer, error) {
+	return hmacWithFormat(ctx, in, algs, keys, []string{"hex"})
+}
+
+func HMACWithFormat(ctx *context.Context, in context.Valuer, algs, keys, formats []context.Valuer) ([]context.Valuer, error) {
+	fs, err := stringsOf(ctx, formats)
+	if err != nil {
+		return nil, err
+	}
+
+	return hmacWithFormat(ctx, in, algs, keys, fs)
+}
@@ -0,0 +1,59 @@
+// Package hash provides cryptographic and non-cryptographic hashes, HMACs and
+// a hash of the canonical JSON representation of any value.
+//
+// Digests are returned as lowercase hex strings by default. Functions that
+// take a format accept "hex" or "raw", which returns the digest as bytes.
+package hash
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	gohash "hash"
+	"hash/crc32"
+	"hash/fnv"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+// algorithms maps the names of the supported hash algorithms to their
+// constructors. "crc32" is the IEEE polynomial and "fnv" is 64-bit FNV-1a.
+var algorithms = map[string]func() gohash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha224": sha256.New224,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+	"crc32":  func() gohash.Hash { return crc32.NewIEEE() },
+	"fnv":    func() gohash.Hash { return fnv.New64a() },
+	"fnv32":  func() gohash.Hash { return fnv.New32a() },
+}
+
+// digestFunctions are the algorithms that are also available as functions
+// named after them.
+var digestFunctions = []string{"crc32", "fnv", "md5", "sha1", "sha256", "sha512"}
+
+func DefineIn(ctx *context.Context) {
+	for _, name := range digestFunctions {
+		fn, _ := function.NewFunction(newDigest(name))
+		ctx.DefineFunction(name, fn)
+
+		fn, _ = function.NewFunction(newDigestWithFormat(name))
+		ctx.DefineFunction(name, fn)
+	}
+
+	fn, _ := function.NewFunction(Hash)
+	ctx.DefineFunction("hash", fn)
+
+	fn, _ = function.NewFunction(HashWithAlgorithm)
+	ctx.DefineFunction("hash", fn)
+
+	fn, _ = function.NewFunction(HMAC)
+	ctx.DefineFunction("hmac", fn)
+
+	fn, _ = function.NewFunction(HMACWithFormat)
+	ctx.DefineFunction("hmac", fn)
+}
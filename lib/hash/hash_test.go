@@ -0,0 +1,133 @@
+package hash
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigest(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	conds := map[string]string{
+		"md5":    "900150983cd24fb0d6963f7d28e17f72",
+		"sha1":   "a9993e364706816aba3e25717850c26c9cd0d89d",
+		"sha256": "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		"crc32":  "352441c2",
+		"fnv":    "e71fa2190541574b",
+	}
+
+	for name, expected := range conds {
+		vrs, err := newDigest(name)(ctx, context.NewConstValuer(types.Bytes("abc")))
+		assert.NoError(t, err, name)
+		assert.Equal(t, []context.Valuer{context.NewConstValuer(types.Str(expected))}, vrs, name)
+	}
+
+	vrs, err := digest(ctx, context.NewConstValuer("abc"), "crc32", []string{"raw"})
+	assert.NoError(t, err)
+	assert.Equal(t, []context.Valuer{context.NewConstValuer(types.Bytes{0x35, 0x24, 0x41, 0xc2})}, vrs)
+
+	_, err = digest(ctx, context.NewConstValuer("abc"), "md4", []string{"hex"})
+	assert.IsType(t, &UnknownAlgorithmError{}, errors.Cause(err))
+}
+
+func TestHMAC(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	// From RFC 4231, test case 2.
+	vrs, err := HMAC(
+		ctx,
+		context.NewConstValuer("what do ya want for nothing?"),
+		[]context.Valuer{context.NewConstValuer("sha256")},
+		[]context.Valuer{context.NewConstValuer("Jefe")},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []context.Valuer{
+		context.NewConstValuer(types.Str("5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843")),
+	}, vrs)
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	a := types.NewObject()
+	a.Set("b", []interface{}{1.0, types.Bytes("<x>")})
+	a.Set("a", types.Number("1.50"))
+
+	b := map[string]interface{}{
+		"a": 1.5,
+		"b": []interface{}{int64(1), "<x>"},
+	}
+
+	ja, err := canonicalJSON(ctx, a)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1.5,"b":[1,"\u003cx\u003e"]}`, string(ja))
+
+	jb, err := canonicalJSON(ctx, b)
+	assert.NoError(t, err)
+	assert.Equal(t, string(ja), string(jb))
+}
+
+func TestCanonicalJSONBytes(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	// Invalid UTF-8 must not be replaced with U+FFFD, or these would collide.
+	ins := []interface{}{
+		types.Bytes{0xff},
+		types.Bytes{0xfe},
+		types.Bytes{0xef, 0xbf, 0xbd},
+		types.Str("�"),
+	}
+
+	seen := make(map[string]int)
+	for i, in := range ins {
+		b, err := canonicalJSON(ctx, in)
+		assert.NoError(t, err)
+		seen[string(b)] = i
+	}
+	assert.Len(t, seen, 3)
+
+	b, err := canonicalJSON(ctx, types.Bytes{0xff})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"$bytes":"/w=="}`, string(b))
+
+	// An object that looks like tagged bytes is escaped.
+	o := types.NewObject()
+	o.Set("$bytes", "/w==")
+
+	b, err = canonicalJSON(ctx, o)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"$$bytes":"/w=="}`, string(b))
+}
+
+func TestCanonicalJSONEqualValues(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	utc := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	pairs := []struct {
+		A, B interface{}
+	}{
+		{types.Time{Time: utc}, types.Time{Time: utc.In(time.FixedZone("UTC+2", 2*60*60))}},
+		{types.Semver{Major: 1, Build: []string{"a"}}, types.Semver{Major: 1, Build: []string{"b"}}},
+	}
+
+	for _, pair := range pairs {
+		ja, err := canonicalJSON(ctx, pair.A)
+		assert.NoError(t, err)
+
+		jb, err := canonicalJSON(ctx, pair.B)
+		assert.NoError(t, err)
+
+		assert.Equal(t, string(ja), string(jb))
+	}
+}
@@ -1,8 +1,12 @@
 package io
 
 import (
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/reflect/filq/context"
@@ -20,7 +24,23 @@ func (rf *readFile) Value(ctx *context.Context) (interface{}, error) {
 	}
 	defer f.Close()
 
-	b, err := ioutil.ReadAll(f)
+	// Compressed files are decompressed transparently based on their
+	// extension.
+	var r io.Reader = f
+	switch {
+	case strings.HasSuffix(rf.path, ".gz"):
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decompressing file %s", rf.path)
+		}
+		defer gr.Close()
+
+		r = gr
+	case strings.HasSuffix(rf.path, ".bz2"):
+		r = bzip2.NewReader(f)
+	}
+
+	b, err := ioutil.ReadAll(r)
 	if err != nil {
 		return nil, errors.Wrapf(err, "reading file %s", rf.path)
 	}
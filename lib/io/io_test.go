@@ -0,0 +1,98 @@
+package io
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// bzip2Fixture is "hello, world\n" compressed with bzip2. The standard
+// library can only decompress bzip2, so it is stored precompressed.
+const bzip2Fixture = "425a683931415926535954a49784000002d180001040040644908020003100302068620049d4b21f3f17724538509054a49784"
+
+func writeFixture(t *testing.T, dir, name string, b []byte) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, ioutil.WriteFile(path, b, 0644))
+
+	return path
+}
+
+func readFixture(ctx *context.Context, path string) (interface{}, error) {
+	vrs, err := ReadFile(ctx, context.NewConstValuer(types.Str(path)))
+	if err != nil {
+		return nil, err
+	}
+
+	return vrs[0].Value(ctx)
+}
+
+func TestReadFileDecompresses(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	dir, err := ioutil.TempDir("", "filq-io")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	want := ctx.Convert([]byte("hello, world\n"))
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, err = gw.Write([]byte("hello, world\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, gw.Close())
+
+	bz, err := hex.DecodeString(bzip2Fixture)
+	assert.NoError(t, err)
+
+	paths := []string{
+		writeFixture(t, dir, "plain.txt", []byte("hello, world\n")),
+		writeFixture(t, dir, "fixture.txt.gz", gz.Bytes()),
+		writeFixture(t, dir, "fixture.txt.bz2", bz),
+	}
+
+	for _, path := range paths {
+		out, err := readFixture(ctx, path)
+		if assert.NoError(t, err, path) {
+			assert.Equal(t, want, out, path)
+		}
+	}
+}
+
+func TestReadFileCorrupt(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	dir, err := ioutil.TempDir("", "filq-io")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, err = gw.Write([]byte("hello, world\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, gw.Close())
+
+	// A gzip stream whose trailing checksum does not match its contents.
+	corrupt := gz.Bytes()
+	corrupt[len(corrupt)-8] ^= 0xff
+
+	paths := []string{
+		writeFixture(t, dir, "notgzip.gz", []byte("hello, world\n")),
+		writeFixture(t, dir, "checksum.gz", corrupt),
+		writeFixture(t, dir, "notbzip2.bz2", []byte("hello, world\n")),
+	}
+
+	for _, path := range paths {
+		_, err := readFixture(ctx, path)
+		assert.Error(t, err, path)
+	}
+}
@@ -12,6 +12,59 @@ import (
 	"github.com/reflect/filq/types"
 )
 
+// decode reads the next JSON value from the decoder. Unlike decoding into an
+// interface{}, objects are decoded into types.Object so that the order of
+// their keys is retained.
+func decode(d *json.Decoder) (interface{}, error) {
+	t, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case json.Delim('{'):
+		o := types.NewObject()
+		for d.More() {
+			kt, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decode(d)
+			if err != nil {
+				return nil, err
+			}
+
+			o.Set(kt.(string), value)
+		}
+
+		// Consume the closing delimiter.
+		if _, err := d.Token(); err != nil {
+			return nil, err
+		}
+
+		return o, nil
+	case json.Delim('['):
+		a := []interface{}{}
+		for d.More() {
+			value, err := decode(d)
+			if err != nil {
+				return nil, err
+			}
+
+			a = append(a, value)
+		}
+
+		if _, err := d.Token(); err != nil {
+			return nil, err
+		}
+
+		return a, nil
+	default:
+		return t, nil
+	}
+}
+
 func from(v interface{}) (context.Valuer, error) {
 	d, ok := v.(*json.Decoder)
 	if !ok {
@@ -37,9 +90,13 @@ func from(v interface{}) (context.Valuer, error) {
 		}
 	}
 
+	// Numbers are decoded as literals so that the context can decide how to
+	// represent them.
+	d.UseNumber()
+
 	lazy := func(ctx *context.Context) (interface{}, error) {
-		var out interface{}
-		if err := d.Decode(&out); err != nil {
+		out, err := decode(d)
+		if err != nil {
 			return nil, errors.Wrap(err, "parsing JSON")
 		}
 
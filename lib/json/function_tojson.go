@@ -13,7 +13,12 @@ type toJSON struct {
 }
 
 func (tj *toJSON) Value(ctx *context.Context) (interface{}, error) {
-	b, err := json.Marshal(tj.v)
+	v := tj.v
+	if sortKeys, _ := ctx.Option(types.OptionSortKeys).(bool); sortKeys {
+		v = types.SortKeys(v)
+	}
+
+	b, err := json.Marshal(v)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshaling JSON")
 	}
@@ -0,0 +1,275 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// change is one step in transforming a value into another. It corresponds to
+// a JSON Patch add, remove, replace or move operation, and also records the
+// value being replaced or removed.
+type change struct {
+	op       string
+	path     pointer
+	from     pointer
+	old, new interface{}
+}
+
+func (c *change) patchOp() *types.Object {
+	o := types.NewObject()
+	o.Set("op", c.op)
+	if c.op == "move" {
+		o.Set("from", c.from.String())
+	}
+	o.Set("path", c.path.String())
+
+	switch c.op {
+	case "add", "replace":
+		o.Set("value", c.new)
+	}
+
+	return o
+}
+
+func (c *change) record() *types.Object {
+	o := types.NewObject()
+	o.Set("op", c.op)
+	if c.op == "move" {
+		o.Set("from", c.from.String())
+	}
+	o.Set("path", c.path.String())
+
+	switch c.op {
+	case "remove":
+		o.Set("old", c.old)
+	case "replace":
+		o.Set("old", c.old)
+		o.Set("new", c.new)
+	case "add":
+		o.Set("new", c.new)
+	}
+
+	return o
+}
+
+type differ struct {
+	ctx *context.Context
+
+	// key is the name of the member used to match up objects in arrays, or
+	// the empty string to match array elements by position.
+	key string
+
+	changes []*change
+}
+
+func (d *differ) emit(c *change) {
+	d.changes = append(d.changes, c)
+}
+
+func (d *differ) diff(p pointer, a, b interface{}) error {
+	a, b = d.ctx.Convert(a), d.ctx.Convert(b)
+
+	eq, err := types.Equal(d.ctx, a, b)
+	if err != nil {
+		return err
+	} else if eq {
+		return nil
+	}
+
+	switch at := a.(type) {
+	case *types.Object:
+		if bt, ok := b.(*types.Object); ok {
+			return d.diffObjects(p, at, bt)
+		}
+	case types.Array:
+		if bt, ok := b.(types.Array); ok {
+			if d.key != "" {
+				if ak, ok := d.keysOf(at); ok {
+					if bk, ok := d.keysOf(bt); ok {
+						return d.diffKeyedArrays(p, at, bt, ak, bk)
+					}
+				}
+			}
+
+			return d.diffArrays(p, at, bt)
+		}
+	}
+
+	d.emit(&change{op: "replace", path: p, old: a, new: b})
+	return nil
+}
+
+func (d *differ) diffObjects(p pointer, a, b *types.Object) error {
+	for _, key := range a.Keys() {
+		av, _ := a.Get(key)
+		if bv, ok := b.Get(key); ok {
+			if err := d.diff(append(p[:len(p):len(p)], key), av, bv); err != nil {
+				return err
+			}
+		} else {
+			d.emit(&change{op: "remove", path: append(p[:len(p):len(p)], key), old: d.ctx.Convert(av)})
+		}
+	}
+
+	for _, key := range b.Keys() {
+		if _, ok := a.Get(key); !ok {
+			bv, _ := b.Get(key)
+			d.emit(&change{op: "add", path: append(p[:len(p):len(p)], key), new: d.ctx.Convert(bv)})
+		}
+	}
+
+	return nil
+}
+
+func elementPath(p pointer, i int) pointer {
+	return append(p[:len(p):len(p)], strconv.Itoa(i))
+}
+
+// diffArrays matches elements by position, after skipping any common prefix
+// and suffix so that insertions and removals do not shift every following
+// element.
+func (d *differ) diffArrays(p pointer, a, b types.Array) error {
+	equal := func(x, y interface{}) (bool, error) {
+		return types.Equal(d.ctx, d.ctx.Convert(x), d.ctx.Convert(y))
+	}
+
+	start := 0
+	for start < len(a) && start < len(b) {
+		eq, err := equal(a[start], b[start])
+		if err != nil {
+			return err
+		} else if !eq {
+			break
+		}
+
+		start++
+	}
+
+	end := 0
+	for end < len(a)-start && end < len(b)-start {
+		eq, err := equal(a[len(a)-1-end], b[len(b)-1-end])
+		if err != nil {
+			return err
+		} else if !eq {
+			break
+		}
+
+		end++
+	}
+
+	ma, mb := a[start:len(a)-end], b[start:len(b)-end]
+
+	n := len(ma)
+	if len(mb) < n {
+		n = len(mb)
+	}
+
+	for i := 0; i < n; i++ {
+		if err := d.diff(elementPath(p, start+i), ma[i], mb[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := len(ma) - 1; i >= n; i-- {
+		d.emit(&change{op: "remove", path: elementPath(p, start+i), old: d.ctx.Convert(ma[i])})
+	}
+
+	for i := n; i < len(mb); i++ {
+		d.emit(&change{op: "add", path: elementPath(p, start+i), new: d.ctx.Convert(mb[i])})
+	}
+
+	return nil
+}
+
+// keysOf returns the JSON representation of the key member of each element
+// of an array. It fails if any element is not an object with the key member
+// or if two elements have the same key.
+func (d *differ) keysOf(a types.Array) ([]string, bool) {
+	keys := make([]string, len(a))
+	seen := make(map[string]bool, len(a))
+
+	for i, v := range a {
+		o, ok := d.ctx.Convert(v).(*types.Object)
+		if !ok {
+			return nil, false
+		}
+
+		kv, ok := o.Get(d.key)
+		if !ok {
+			return nil, false
+		}
+
+		b, err := json.Marshal(d.ctx.Convert(kv))
+		if err != nil || seen[string(b)] {
+			return nil, false
+		}
+
+		keys[i], seen[string(b)] = string(b), true
+	}
+
+	return keys, true
+}
+
+// diffKeyedArrays matches objects by the value of their key member. Objects
+// whose key only appears in a are removed, objects are moved into the
+// position they have in b and then compared, and objects whose key only
+// appears in b are added.
+func (d *differ) diffKeyedArrays(p pointer, a, b types.Array, ak, bk []string) error {
+	inB := make(map[string]bool, len(bk))
+	for _, k := range bk {
+		inB[k] = true
+	}
+
+	byKey := make(map[string]interface{}, len(ak))
+	for i, k := range ak {
+		byKey[k] = a[i]
+	}
+
+	// cur tracks the keys of the array as the changes are applied.
+	var cur []string
+	for i := len(ak) - 1; i >= 0; i-- {
+		if !inB[ak[i]] {
+			d.emit(&change{op: "remove", path: elementPath(p, i), old: d.ctx.Convert(a[i])})
+		}
+	}
+	for _, k := range ak {
+		if inB[k] {
+			cur = append(cur, k)
+		}
+	}
+
+	for j, k := range bk {
+		i := -1
+		for ci := j; ci < len(cur); ci++ {
+			if cur[ci] == k {
+				i = ci
+				break
+			}
+		}
+
+		if i < 0 {
+			d.emit(&change{op: "add", path: elementPath(p, j), new: d.ctx.Convert(b[j])})
+
+			cur = append(cur, "")
+			copy(cur[j+1:], cur[j:])
+			cur[j] = k
+			continue
+		}
+
+		if i != j {
+			d.emit(&change{op: "move", from: elementPath(p, i), path: elementPath(p, j)})
+
+			copy(cur[j+1:i+1], cur[j:i])
+			cur[j] = k
+		}
+
+		if err := d.diff(elementPath(p, j), byKey[k], b[j]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package jsonpatch
+
+import (
+	"testing"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func diffOne(t *testing.T, ctx *context.Context, a, b, options string) string {
+	l, err := DiffWithOptions(ctx, context.NewConstValuer(nil),
+		[]context.Valuer{context.NewConstValuer(parse(t, ctx, a))},
+		[]context.Valuer{context.NewConstValuer(parse(t, ctx, b))},
+		[]context.Valuer{context.NewConstValuer(parse(t, ctx, options))})
+	assert.NoError(t, err)
+
+	v, err := l[0].Value(ctx)
+	assert.NoError(t, err)
+
+	return marshal(t, v)
+}
+
+func TestDiff(t *testing.T) {
+	ctx := newContext()
+
+	conds := []struct {
+		A, B, Options, Expected string
+	}{
+		{`{"a":1}`, `{"a":1}`, `{}`, `[]`},
+		{`1`, `"1"`, `{}`, `[{"op":"replace","path":"","value":"1"}]`},
+		{
+			`{"a":1,"b":{"c":2},"d":3}`,
+			`{"a":1,"b":{"c":4},"e":5}`,
+			`{}`,
+			`[{"op":"replace","path":"/b/c","value":4},{"op":"remove","path":"/d"},{"op":"add","path":"/e","value":5}]`,
+		},
+		{`[1,2,3]`, `[1,9,2,3]`, `{}`, `[{"op":"add","path":"/1","value":9}]`},
+		{`[1,2,3,4]`, `[1,4]`, `{}`, `[{"op":"remove","path":"/2"},{"op":"remove","path":"/1"}]`},
+		{`{"a/b":[1]}`, `{"a/b":[2]}`, `{}`, `[{"op":"replace","path":"/a~1b/0","value":2}]`},
+		{
+			`{"a":1,"b":{"c":2}}`,
+			`{"b":{"c":3}}`,
+			`{"format":"changes"}`,
+			`[{"op":"remove","path":"/a","old":1},{"op":"replace","path":"/b/c","old":2,"new":3}]`,
+		},
+		{
+			`[{"id":1,"v":"a"},{"id":2,"v":"b"},{"id":3,"v":"c"}]`,
+			`[{"id":3,"v":"c"},{"id":1,"v":"x"},{"id":4,"v":"d"}]`,
+			`{"key":"id"}`,
+			`[{"op":"remove","path":"/1"},{"op":"move","from":"/1","path":"/0"},{"op":"replace","path":"/1/v","value":"x"},{"op":"add","path":"/2","value":{"id":4,"v":"d"}}]`,
+		},
+		{
+			`[{"id":1},{"id":1}]`,
+			`[{"id":1}]`,
+			`{"key":"id"}`,
+			`[{"op":"remove","path":"/1"}]`,
+		},
+	}
+
+	for _, cond := range conds {
+		assert.Equal(t, cond.Expected, diffOne(t, ctx, cond.A, cond.B, cond.Options), "%s -> %s", cond.A, cond.B)
+	}
+
+	for _, options := range []string{`{"format":"yaml"}`, `{"key":1}`, `{"keys":"id"}`, `[]`} {
+		_, err := DiffWithOptions(ctx, context.NewConstValuer(nil),
+			[]context.Valuer{context.NewConstValuer(nil)},
+			[]context.Valuer{context.NewConstValuer(nil)},
+			[]context.Valuer{context.NewConstValuer(parse(t, ctx, options))})
+		assert.Error(t, err, options)
+	}
+}
+
+func TestDiffPatchRoundTrip(t *testing.T) {
+	ctx := newContext()
+
+	conds := []struct {
+		A, B string
+	}{
+		{`{"a":[1,2,3],"b":{"c":null}}`, `{"b":{"c":[]},"a":[3,2,1,0]}`},
+		{`[]`, `[{"x":[1]}]`},
+		{`[1,[2,3],4]`, `[[2],4,5]`},
+		{`[{"id":"a","n":1},{"id":"b","n":2},{"id":"c","n":3}]`, `[{"id":"c","n":3},{"id":"d"},{"id":"a","n":4}]`},
+		{`[{"id":"a"},{"id":"b"},{"id":"c"},{"id":"d"}]`, `[{"id":"d"},{"id":"c"},{"id":"b"},{"id":"a"}]`},
+		{`{"list":[{"id":1,"tags":[{"id":"t"}]}]}`, `{"list":[{"id":2},{"id":1,"tags":[{"id":"u"},{"id":"t"}]}]}`},
+	}
+
+	for _, cond := range conds {
+		for _, options := range []string{`{}`, `{"key":"id"}`} {
+			ops := diffOne(t, ctx, cond.A, cond.B, options)
+
+			l, err := Patch(ctx, context.NewConstValuer(parse(t, ctx, cond.A)), []context.Valuer{context.NewConstValuer(parse(t, ctx, ops))})
+			if !assert.NoError(t, err, ops) {
+				continue
+			}
+
+			v, err := l[0].Value(ctx)
+			assert.NoError(t, err)
+
+			eq, err := types.Equal(ctx, parse(t, ctx, cond.B), v)
+			assert.NoError(t, err)
+			assert.True(t, eq, "%s with %s: %s", cond.A, options, ops)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package jsonpatch
+
+import (
+	"fmt"
+)
+
+type InvalidPointerError struct {
+	Pointer string
+	Reason  string
+}
+
+func (e *InvalidPointerError) Error() string {
+	return fmt.Sprintf("invalid JSON pointer %q: %s", e.Pointer, e.Reason)
+}
+
+type PointerNotFoundError struct {
+	Pointer string
+}
+
+func (e *PointerNotFoundError) Error() string {
+	return fmt.Sprintf("JSON pointer %q does not exist", e.Pointer)
+}
+
+type InvalidOperationError struct {
+	Index  int
+	Reason string
+}
+
+func (e *InvalidOperationError) Error() string {
+	return fmt.Sprintf("invalid patch operation %d: %s", e.Index, e.Reason)
+}
+
+type TestFailedError struct {
+	Index int
+	Path  string
+}
+
+func (e *TestFailedError) Error() string {
+	return fmt.Sprintf("patch operation %d: test of %q failed", e.Index, e.Path)
+}
+
+type InvalidDiffOptionError struct {
+	Option string
+	Reason string
+}
+
+func (e *InvalidDiffOptionError) Error() string {
+	return fmt.Sprintf("invalid diff option %q: %s", e.Option, e.Reason)
+}
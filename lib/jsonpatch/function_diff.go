@@ -0,0 +1,112 @@
+package jsonpatch
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+type diffOptions struct {
+	// changes selects the change list output instead of a JSON Patch.
+	changes bool
+	key     string
+}
+
+func diffOptionsOf(ctx *context.Context, v interface{}) (*diffOptions, error) {
+	o, ok := ctx.Convert(v).(*types.Object)
+	if !ok {
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{reflect.TypeOf(&types.Object{})},
+			Got:    reflect.TypeOf(v),
+		})
+	}
+
+	opts := &diffOptions{}
+	for _, name := range o.Keys() {
+		value, _ := o.Get(name)
+
+		switch name {
+		case "format":
+			switch ctx.Convert(value) {
+			case types.Str("patch"):
+			case types.Str("changes"):
+				opts.changes = true
+			default:
+				return nil, errors.WithStack(&InvalidDiffOptionError{Option: name, Reason: `must be "patch" or "changes"`})
+			}
+		case "key":
+			switch kt := ctx.Convert(value).(type) {
+			case nil:
+			case types.Str:
+				opts.key = string(kt)
+			default:
+				return nil, errors.WithStack(&InvalidDiffOptionError{Option: name, Reason: "must be a string"})
+			}
+		default:
+			return nil, errors.WithStack(&InvalidDiffOptionError{Option: name, Reason: "unknown option"})
+		}
+	}
+
+	return opts, nil
+}
+
+func Diff(ctx *context.Context, in context.Valuer, as, bs []context.Valuer) ([]context.Valuer, error) {
+	return DiffWithOptions(ctx, in, as, bs, []context.Valuer{context.NewConstValuer(types.NewObject())})
+}
+
+// DiffWithOptions returns the changes that transform the first value into the
+// second. By default the changes are a JSON Patch. With the format option
+// "changes" they are instead a list of objects with op, path, old and new
+// keys, where old and new are the values before and after each change.
+//
+// Arrays are compared element by element. With the key option, arrays of
+// objects that all have that member, with distinct values, are instead
+// compared by matching up objects with the same value for it.
+func DiffWithOptions(ctx *context.Context, in context.Valuer, as, bs, options []context.Valuer) ([]context.Valuer, error) {
+	var out []context.Valuer
+	for _, option := range options {
+		ov, err := option.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		opts, err := diffOptionsOf(ctx, ov)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, a := range as {
+			av, err := a.Value(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, b := range bs {
+				bv, err := b.Value(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				d := &differ{ctx: ctx, key: opts.key}
+				if err := d.diff(pointer{}, av, bv); err != nil {
+					return nil, err
+				}
+
+				r := types.Array{}
+				for _, c := range d.changes {
+					if opts.changes {
+						r = append(r, c.record())
+					} else {
+						r = append(r, c.patchOp())
+					}
+				}
+
+				out = append(out, context.NewConstValuer(r))
+			}
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,39 @@
+package jsonpatch
+
+import (
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+)
+
+// GetPointer returns the value that a JSON pointer refers to in the input, or
+// null if there is no such value.
+func GetPointer(ctx *context.Context, in context.Valuer, pointers []context.Valuer) ([]context.Valuer, error) {
+	doc, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, ptr := range pointers {
+		pv, err := ptr.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := pointerOf(pv)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := get(ctx, doc, p)
+		if _, ok := errors.Cause(err).(*PointerNotFoundError); ok {
+			v = nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		out = append(out, context.NewConstValuer(v))
+	}
+
+	return out, nil
+}
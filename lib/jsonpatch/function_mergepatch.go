@@ -0,0 +1,26 @@
+package jsonpatch
+
+import (
+	"github.com/reflect/filq/context"
+)
+
+// MergePatch applies a JSON merge patch to the input. Members of the patch
+// replace members of the input recursively, and null members remove them.
+func MergePatch(ctx *context.Context, in context.Valuer, patches []context.Valuer) ([]context.Valuer, error) {
+	doc, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, patch := range patches {
+		pv, err := patch.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, context.NewConstValuer(mergePatch(ctx, clone(ctx, doc), pv)))
+	}
+
+	return out, nil
+}
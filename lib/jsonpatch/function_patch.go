@@ -0,0 +1,154 @@
+package jsonpatch
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// applyOp applies one JSON Patch operation to a document.
+func applyOp(ctx *context.Context, doc interface{}, i int, op *types.Object) (interface{}, error) {
+	member := func(name string) (interface{}, bool) {
+		v, ok := op.Get(name)
+		return ctx.Convert(v), ok
+	}
+
+	pointerMember := func(name string) (pointer, error) {
+		v, ok := member(name)
+		if !ok {
+			return nil, errors.WithStack(&InvalidOperationError{Index: i, Reason: "missing " + name})
+		}
+
+		s, ok := v.(types.Str)
+		if !ok {
+			return nil, errors.WithStack(&InvalidOperationError{Index: i, Reason: name + " must be a string"})
+		}
+
+		return parsePointer(string(s))
+	}
+
+	valueMember := func() (interface{}, error) {
+		v, ok := member("value")
+		if !ok {
+			return nil, errors.WithStack(&InvalidOperationError{Index: i, Reason: "missing value"})
+		}
+
+		return clone(ctx, v), nil
+	}
+
+	name, _ := member("op")
+	path, err := pointerMember("path")
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case types.Str("add"):
+		value, err := valueMember()
+		if err != nil {
+			return nil, err
+		}
+
+		return add(ctx, doc, path, value)
+	case types.Str("remove"):
+		return remove(ctx, doc, path)
+	case types.Str("replace"):
+		value, err := valueMember()
+		if err != nil {
+			return nil, err
+		}
+
+		return replace(ctx, doc, path, value)
+	case types.Str("move"), types.Str("copy"):
+		from, err := pointerMember("from")
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := get(ctx, doc, from)
+		if err != nil {
+			return nil, err
+		}
+
+		if name == types.Str("copy") {
+			return add(ctx, doc, path, clone(ctx, value))
+		}
+
+		if path.hasPrefix(from) && len(path) > len(from) {
+			return nil, errors.WithStack(&InvalidOperationError{Index: i, Reason: "cannot move a value into one of its children"})
+		}
+
+		doc, err = remove(ctx, doc, from)
+		if err != nil {
+			return nil, err
+		}
+
+		return add(ctx, doc, path, value)
+	case types.Str("test"):
+		value, err := valueMember()
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := get(ctx, doc, path)
+		if err != nil {
+			return nil, err
+		}
+
+		eq, err := types.Equal(ctx, current, value)
+		if err != nil {
+			return nil, err
+		} else if !eq {
+			return nil, errors.WithStack(&TestFailedError{Index: i, Path: path.String()})
+		}
+
+		return doc, nil
+	default:
+		return nil, errors.WithStack(&InvalidOperationError{Index: i, Reason: "unknown op"})
+	}
+}
+
+// Patch applies a JSON Patch document, an array of operations, to the input.
+// The operations are applied in order to a copy of the input, and if any of
+// them fails the whole patch fails.
+func Patch(ctx *context.Context, in context.Valuer, patches []context.Valuer) ([]context.Valuer, error) {
+	doc, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, patch := range patches {
+		pv, err := patch.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ops, ok := ctx.Convert(pv).(types.Array)
+		if !ok {
+			return nil, errors.WithStack(&context.UnexpectedTypeError{
+				Wanted: []reflect.Type{reflect.TypeOf(types.Array{})},
+				Got:    reflect.TypeOf(pv),
+			})
+		}
+
+		r := clone(ctx, doc)
+		for i, op := range ops {
+			o, ok := ctx.Convert(op).(*types.Object)
+			if !ok {
+				return nil, errors.WithStack(&InvalidOperationError{Index: i, Reason: "operation must be an object"})
+			}
+
+			r, err = applyOp(ctx, r, i, o)
+			if err != nil {
+				return nil, errors.Wrapf(err, "applying patch operation %d", i)
+			}
+		}
+
+		out = append(out, context.NewConstValuer(r))
+	}
+
+	return out, nil
+}
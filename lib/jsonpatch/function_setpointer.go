@@ -0,0 +1,44 @@
+package jsonpatch
+
+import (
+	"github.com/reflect/filq/context"
+)
+
+// SetPointer returns a copy of the input in which the location a JSON pointer
+// refers to is set to the given value. The container of that location must
+// already exist. Array elements are replaced; "-" appends to an array.
+func SetPointer(ctx *context.Context, in context.Valuer, pointers, values []context.Valuer) ([]context.Valuer, error) {
+	doc, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, ptr := range pointers {
+		pv, err := ptr.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := pointerOf(pv)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, value := range values {
+			v, err := value.Value(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			r, err := set(ctx, clone(ctx, doc), p, clone(ctx, v))
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, context.NewConstValuer(r))
+		}
+	}
+
+	return out, nil
+}
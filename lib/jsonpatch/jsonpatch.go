@@ -0,0 +1,34 @@
+// Package jsonpatch reads and modifies values using JSON Pointers (RFC 6901),
+// JSON Patch (RFC 6902) and JSON Merge Patch (RFC 7386).
+//
+// Modifications never change their input. A patch either applies completely
+// or fails with an error, in which case no partial result is produced.
+//
+// Patches can also be computed: diff describes the differences between two
+// values as a JSON Patch.
+package jsonpatch
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(Diff)
+	ctx.DefineFunction("diff", fn)
+
+	fn, _ = function.NewFunction(DiffWithOptions)
+	ctx.DefineFunction("diff", fn)
+
+	fn, _ = function.NewFunction(GetPointer)
+	ctx.DefineFunction("getpointer", fn)
+
+	fn, _ = function.NewFunction(MergePatch)
+	ctx.DefineFunction("mergepatch", fn)
+
+	fn, _ = function.NewFunction(Patch)
+	ctx.DefineFunction("patch", fn)
+
+	fn, _ = function.NewFunction(SetPointer)
+	ctx.DefineFunction("setpointer", fn)
+}
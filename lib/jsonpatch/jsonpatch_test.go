@@ -0,0 +1,213 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	filqjson "github.com/reflect/filq/lib/json"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContext() *context.Context {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+	return ctx
+}
+
+func parse(t *testing.T, ctx *context.Context, s string) interface{} {
+	vr, err := filqjson.NewValuer(types.Str(s))
+	assert.NoError(t, err)
+
+	v, err := vr.Value(ctx)
+	assert.NoError(t, err)
+
+	return v
+}
+
+func marshal(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+
+	return string(b)
+}
+
+func TestPointer(t *testing.T) {
+	ctx := newContext()
+	doc := parse(t, ctx, `{"foo":["bar","baz"],"":0,"a/b":1,"c%d":2,"e^f":3,"g|h":4,"i\\j":5,"k\"l":6," ":7,"m~n":8}`)
+
+	conds := []struct {
+		Pointer, Expected string
+	}{
+		{"", marshal(t, doc)},
+		{"/foo", `["bar","baz"]`},
+		{"/foo/0", `"bar"`},
+		{"/", `0`},
+		{"/a~1b", `1`},
+		{"/c%d", `2`},
+		{"/e^f", `3`},
+		{"/g|h", `4`},
+		{"/i\\j", `5`},
+		{"/k\"l", `6`},
+		{"/ ", `7`},
+		{"/m~0n", `8`},
+		{"/missing", `null`},
+		{"/foo/2", `null`},
+		{"/foo/01", `null`},
+		{"/foo/-", `null`},
+	}
+
+	for _, cond := range conds {
+		l, err := GetPointer(ctx, context.NewConstValuer(doc), []context.Valuer{context.NewConstValuer(types.Str(cond.Pointer))})
+		assert.NoError(t, err, cond.Pointer)
+
+		v, err := l[0].Value(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, marshal(t, v), cond.Pointer)
+	}
+
+	for _, s := range []string{"foo", "/a~2b", "/a~"} {
+		_, err := parsePointer(s)
+		assert.Error(t, err, s)
+	}
+
+	p, err := parsePointer("/a~1b/m~0n/~01")
+	assert.NoError(t, err)
+	assert.Equal(t, pointer{"a/b", "m~n", "~1"}, p)
+	assert.Equal(t, "/a~1b/m~0n/~01", p.String())
+}
+
+func TestSetPointer(t *testing.T) {
+	ctx := newContext()
+	doc := parse(t, ctx, `{"a":{"b":[1,2]}}`)
+
+	conds := []struct {
+		Pointer, Value, Expected string
+	}{
+		{"/a/c", `true`, `{"a":{"b":[1,2],"c":true}}`},
+		{"/a/b/0", `9`, `{"a":{"b":[9,2]}}`},
+		{"/a/b/-", `3`, `{"a":{"b":[1,2,3]}}`},
+		{"/a/b/2", `3`, `{"a":{"b":[1,2,3]}}`},
+		{"", `[]`, `[]`},
+		{"/x/y", `1`, ``},
+		{"/a/b/3", `1`, ``},
+	}
+
+	for _, cond := range conds {
+		l, err := SetPointer(ctx, context.NewConstValuer(doc),
+			[]context.Valuer{context.NewConstValuer(types.Str(cond.Pointer))},
+			[]context.Valuer{context.NewConstValuer(parse(t, ctx, cond.Value))})
+		if cond.Expected == "" {
+			assert.Error(t, err, cond.Pointer)
+			continue
+		}
+
+		assert.NoError(t, err, cond.Pointer)
+
+		v, err := l[0].Value(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, marshal(t, v), cond.Pointer)
+	}
+
+	assert.Equal(t, `{"a":{"b":[1,2]}}`, marshal(t, doc))
+}
+
+func TestPatch(t *testing.T) {
+	ctx := newContext()
+
+	// Examples from RFC 6902, appendix A.
+	conds := []struct {
+		Doc, Patch, Expected string
+	}{
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"foo":"bar","baz":"qux"}`},
+		{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+		{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+		{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+		{
+			`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			`[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+		{
+			`{"baz":"qux","foo":["a",2,"c"]}`,
+			`[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			`{"baz":"qux","foo":["a",2,"c"]}`,
+		},
+		{`{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, ``},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+		{`{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, ``},
+		{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10}]`, `{"/":9,"~1":10}`},
+		{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+		{`{"a":{"b":1}}`, `[{"op":"copy","from":"/a","path":"/c"},{"op":"replace","path":"/c/b","value":2}]`, `{"a":{"b":1},"c":{"b":2}}`},
+		{`{"a":{"b":1}}`, `[{"op":"move","from":"/a","path":"/a/c"}]`, ``},
+		{`{"a":1}`, `[{"op":"frobnicate","path":"/a"}]`, ``},
+		{`{"a":1}`, `[{"op":"add","path":"/b"}]`, ``},
+	}
+
+	for _, cond := range conds {
+		doc := parse(t, ctx, cond.Doc)
+
+		l, err := Patch(ctx, context.NewConstValuer(doc), []context.Valuer{context.NewConstValuer(parse(t, ctx, cond.Patch))})
+		if cond.Expected == "" {
+			assert.Error(t, err, cond.Patch)
+		} else if assert.NoError(t, err, cond.Patch) {
+			v, err := l[0].Value(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, cond.Expected, marshal(t, v), cond.Patch)
+		}
+
+		// The input is never modified, even by a patch that fails part way.
+		assert.Equal(t, marshal(t, parse(t, ctx, cond.Doc)), marshal(t, doc), cond.Patch)
+	}
+}
+
+func TestPatchAtomic(t *testing.T) {
+	ctx := newContext()
+	doc := parse(t, ctx, `{"a":[1,2]}`)
+
+	_, err := Patch(ctx, context.NewConstValuer(doc), []context.Valuer{context.NewConstValuer(parse(t, ctx,
+		`[{"op":"remove","path":"/a/0"},{"op":"add","path":"/b","value":1},{"op":"test","path":"/b","value":2}]`))})
+	assert.IsType(t, &TestFailedError{}, errors.Cause(err))
+	assert.Equal(t, `{"a":[1,2]}`, marshal(t, doc))
+}
+
+func TestMergePatch(t *testing.T) {
+	ctx := newContext()
+
+	// Examples from RFC 7386, appendix A.
+	conds := []struct {
+		Doc, Patch, Expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, cond := range conds {
+		doc := parse(t, ctx, cond.Doc)
+
+		l, err := MergePatch(ctx, context.NewConstValuer(doc), []context.Valuer{context.NewConstValuer(parse(t, ctx, cond.Patch))})
+		assert.NoError(t, err)
+
+		v, err := l[0].Value(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, marshal(t, v), cond.Patch)
+		assert.Equal(t, marshal(t, parse(t, ctx, cond.Doc)), marshal(t, doc), cond.Patch)
+	}
+}
@@ -0,0 +1,119 @@
+package jsonpatch
+
+import (
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// The functions in this file modify doc in place and return the new
+// document, which differs from doc when the root is replaced or is an array
+// that changes length.
+
+// add adds a value at the given location. Object members are added or
+// replaced and array elements are inserted; "-" appends to an array.
+func add(ctx *context.Context, doc interface{}, p pointer, value interface{}) (interface{}, error) {
+	if len(p) == 0 {
+		return value, nil
+	}
+
+	return update(ctx, doc, p, func(parent interface{}, token string) (interface{}, error) {
+		switch pt := parent.(type) {
+		case *types.Object:
+			pt.Set(token, value)
+			return pt, nil
+		case types.Array:
+			i, ok := index(token, len(pt), true)
+			if !ok || i > len(pt) {
+				return nil, errors.WithStack(&PointerNotFoundError{Pointer: p.String()})
+			}
+
+			pt = append(pt, nil)
+			copy(pt[i+1:], pt[i:])
+			pt[i] = value
+			return pt, nil
+		default:
+			return nil, errors.WithStack(&PointerNotFoundError{Pointer: p.String()})
+		}
+	})
+}
+
+// set sets the value at the given location. Unlike add, array elements are
+// replaced rather than inserted, although "-" or the length of the array
+// still appends.
+func set(ctx *context.Context, doc interface{}, p pointer, value interface{}) (interface{}, error) {
+	if len(p) == 0 {
+		return value, nil
+	}
+
+	return update(ctx, doc, p, func(parent interface{}, token string) (interface{}, error) {
+		if pt, ok := parent.(types.Array); ok {
+			i, ok := index(token, len(pt), true)
+			if ok && i < len(pt) {
+				pt[i] = value
+				return pt, nil
+			}
+		}
+
+		return add(ctx, parent, pointer{token}, value)
+	})
+}
+
+// remove removes the value at the given location, which must exist.
+func remove(ctx *context.Context, doc interface{}, p pointer) (interface{}, error) {
+	if len(p) == 0 {
+		return nil, nil
+	}
+
+	return update(ctx, doc, p, func(parent interface{}, token string) (interface{}, error) {
+		switch pt := parent.(type) {
+		case *types.Object:
+			if _, ok := pt.Get(token); ok {
+				pt.Delete(token)
+				return pt, nil
+			}
+		case types.Array:
+			i, ok := index(token, len(pt), false)
+			if ok && i < len(pt) {
+				return append(pt[:i], pt[i+1:]...), nil
+			}
+		}
+
+		return nil, errors.WithStack(&PointerNotFoundError{Pointer: p.String()})
+	})
+}
+
+// replace replaces the value at the given location, which must exist.
+func replace(ctx *context.Context, doc interface{}, p pointer, value interface{}) (interface{}, error) {
+	if _, err := get(ctx, doc, p); err != nil {
+		return nil, err
+	}
+
+	return set(ctx, doc, p, value)
+}
+
+// mergePatch applies a merge patch to a document.
+func mergePatch(ctx *context.Context, doc, patch interface{}) interface{} {
+	po, ok := ctx.Convert(patch).(*types.Object)
+	if !ok {
+		return clone(ctx, patch)
+	}
+
+	o, ok := ctx.Convert(doc).(*types.Object)
+	if !ok {
+		o = types.NewObject()
+	}
+
+	for _, key := range po.Keys() {
+		value, _ := po.Get(key)
+		if ctx.Convert(value) == nil {
+			o.Delete(key)
+			continue
+		}
+
+		current, _ := o.Get(key)
+		o.Set(key, mergePatch(ctx, current, value))
+	}
+
+	return o
+}
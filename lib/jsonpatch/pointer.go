@@ -0,0 +1,211 @@
+package jsonpatch
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// pointer is a parsed JSON pointer: the unescaped reference tokens.
+type pointer []string
+
+var (
+	escaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	unescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+func parsePointer(s string) (pointer, error) {
+	if s == "" {
+		return pointer{}, nil
+	}
+
+	if s[0] != '/' {
+		return nil, errors.WithStack(&InvalidPointerError{Pointer: s, Reason: `must be empty or start with "/"`})
+	}
+
+	tokens := strings.Split(s[1:], "/")
+	for i, token := range tokens {
+		for j := 0; j < len(token); j++ {
+			if token[j] != '~' {
+				continue
+			}
+
+			if j+1 >= len(token) || (token[j+1] != '0' && token[j+1] != '1') {
+				return nil, errors.WithStack(&InvalidPointerError{Pointer: s, Reason: `"~" must be followed by "0" or "1"`})
+			}
+
+			j++
+		}
+
+		tokens[i] = unescaper.Replace(token)
+	}
+
+	return pointer(tokens), nil
+}
+
+func (p pointer) String() string {
+	var b strings.Builder
+	for _, token := range p {
+		b.WriteByte('/')
+		b.WriteString(escaper.Replace(token))
+	}
+
+	return b.String()
+}
+
+// hasPrefix reports whether q is a prefix of p.
+func (p pointer) hasPrefix(q pointer) bool {
+	if len(q) > len(p) {
+		return false
+	}
+
+	for i := range q {
+		if p[i] != q[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// index parses an array index. The index "-" refers to the position after
+// the last element and is only accepted when end is true; it is returned as
+// the length of the array.
+func index(token string, length int, end bool) (int, bool) {
+	if token == "-" {
+		return length, end
+	}
+
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+
+	for _, c := range token {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+	}
+
+	i, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, false
+	}
+
+	return i, true
+}
+
+// child returns the member of a container referred to by a token.
+func child(ctx *context.Context, v interface{}, token string) (interface{}, bool) {
+	switch vt := ctx.Convert(v).(type) {
+	case *types.Object:
+		return vt.Get(token)
+	case types.Array:
+		i, ok := index(token, len(vt), false)
+		if !ok || i >= len(vt) {
+			return nil, false
+		}
+
+		return vt[i], true
+	default:
+		return nil, false
+	}
+}
+
+// get returns the value a pointer refers to.
+func get(ctx *context.Context, doc interface{}, p pointer) (interface{}, error) {
+	v := doc
+	for i, token := range p {
+		c, ok := child(ctx, v, token)
+		if !ok {
+			return nil, errors.WithStack(&PointerNotFoundError{Pointer: p[:i+1].String()})
+		}
+
+		v = c
+	}
+
+	return ctx.Convert(v), nil
+}
+
+// update replaces the container that holds the value a pointer refers to with
+// the result of fn, which is given the container and the last token of the
+// pointer. Containers along the way are modified in place, so doc must not be
+// shared. The pointer must not be empty.
+func update(ctx *context.Context, doc interface{}, p pointer, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	return updateFrom(ctx, doc, p, 0, fn)
+}
+
+func updateFrom(ctx *context.Context, v interface{}, p pointer, i int, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if i == len(p)-1 {
+		return fn(ctx.Convert(v), p[i])
+	}
+
+	c, ok := child(ctx, v, p[i])
+	if !ok {
+		return nil, errors.WithStack(&PointerNotFoundError{Pointer: p[:i+1].String()})
+	}
+
+	nc, err := updateFrom(ctx, c, p, i+1, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	switch vt := ctx.Convert(v).(type) {
+	case *types.Object:
+		vt.Set(p[i], nc)
+		return vt, nil
+	case types.Array:
+		j, _ := index(p[i], len(vt), false)
+		vt[j] = nc
+		return vt, nil
+	default:
+		return v, nil
+	}
+}
+
+// clone returns a deep copy of a value in which every container has been
+// converted to a types.Object or types.Array.
+func clone(ctx *context.Context, v interface{}) interface{} {
+	switch vt := ctx.Convert(v).(type) {
+	case *types.Object:
+		o := types.NewObject()
+		for _, key := range vt.Keys() {
+			value, _ := vt.Get(key)
+			o.Set(key, clone(ctx, value))
+		}
+
+		return o
+	case types.Array:
+		a := make(types.Array, len(vt))
+		for i, value := range vt {
+			a[i] = clone(ctx, value)
+		}
+
+		return a
+	default:
+		return vt
+	}
+}
+
+func pointerOf(v interface{}) (pointer, error) {
+	var s string
+	switch vt := v.(type) {
+	case types.Str:
+		s = string(vt)
+	case types.Bytes:
+		s = string(vt)
+	default:
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(types.Str("")),
+				reflect.TypeOf(types.Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	return parsePointer(s)
+}
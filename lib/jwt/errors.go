@@ -0,0 +1,40 @@
+package jwt
+
+import (
+	"fmt"
+	"strings"
+)
+
+type MalformedTokenError struct {
+	Reason string
+}
+
+func (e *MalformedTokenError) Error() string {
+	return fmt.Sprintf("malformed JWT: %s", e.Reason)
+}
+
+type UnsupportedAlgorithmError struct {
+	Algorithm string
+}
+
+func (e *UnsupportedAlgorithmError) Error() string {
+	return fmt.Sprintf("unsupported JWT algorithm %q", e.Algorithm)
+}
+
+type DisallowedAlgorithmError struct {
+	Algorithm string
+	Allowed   []string
+}
+
+func (e *DisallowedAlgorithmError) Error() string {
+	return fmt.Sprintf("JWT algorithm %q is not one of %s", e.Algorithm, strings.Join(e.Allowed, ", "))
+}
+
+type InvalidKeyError struct {
+	Algorithm string
+	Reason    string
+}
+
+func (e *InvalidKeyError) Error() string {
+	return fmt.Sprintf("invalid key for JWT algorithm %s: %s", e.Algorithm, e.Reason)
+}
@@ -0,0 +1,22 @@
+package jwt
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// JWTDecode decodes a token without verifying its signature. A "Bearer "
+// prefix, as found in Authorization headers, is ignored.
+func JWTDecode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	t, err := tokenOf(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	o := types.NewObject()
+	o.Set("header", t.header)
+	o.Set("claims", t.claims)
+	o.Set("signature", t.signature)
+
+	return []context.Valuer{context.NewConstValuer(o)}, nil
+}
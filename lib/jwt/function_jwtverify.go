@@ -0,0 +1,189 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+var hashes = map[string]crypto.Hash{
+	"256": crypto.SHA256,
+	"384": crypto.SHA384,
+	"512": crypto.SHA512,
+}
+
+// curveSizes maps ECDSA algorithms to the curve they require, by bit size.
+var curveSizes = map[string]int{
+	"ES256": 256,
+	"ES384": 384,
+	"ES512": 521,
+}
+
+func supported(alg string) bool {
+	if len(alg) != 5 {
+		return false
+	}
+
+	switch alg[:2] {
+	case "HS", "RS", "ES":
+		_, ok := hashes[alg[2:]]
+		return ok
+	default:
+		return false
+	}
+}
+
+// verify reports whether the signature of a token is valid for the key. The
+// algorithm must be supported.
+func verify(t *token, alg string, key interface{}) (bool, error) {
+	h := hashes[alg[2:]]
+
+	sig, err := base64.RawURLEncoding.DecodeString(t.signature)
+	if err != nil {
+		return false, errors.WithStack(&MalformedTokenError{Reason: "signature is not base64url encoded"})
+	}
+
+	switch alg[:2] {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return false, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "expected a shared secret"})
+		}
+
+		mac := hmac.New(h.New, secret)
+		mac.Write([]byte(t.signingInput))
+		return hmac.Equal(sig, mac.Sum(nil)), nil
+	case "RS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return false, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "expected an RSA public key"})
+		}
+
+		d := h.New()
+		d.Write([]byte(t.signingInput))
+		return rsa.VerifyPKCS1v15(pub, h, d.Sum(nil), sig) == nil, nil
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || pub.Curve.Params().BitSize != curveSizes[alg] {
+			return false, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "expected an ECDSA public key on the matching curve"})
+		}
+
+		// The signature is the concatenation of r and s, each padded to the
+		// size of the curve.
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(sig) != 2*size {
+			return false, nil
+		}
+
+		d := h.New()
+		d.Write([]byte(t.signingInput))
+
+		r, s := new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(pub, d.Sum(nil), r, s), nil
+	default:
+		return false, errors.WithStack(&UnsupportedAlgorithmError{Algorithm: alg})
+	}
+}
+
+// algorithmsOf returns the algorithms given as strings or arrays of strings.
+func algorithmsOf(ctx *context.Context, vrs []context.Valuer) ([]string, error) {
+	var out []string
+	for _, vr := range vrs {
+		v, err := vr.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		items, ok := v.(types.Array)
+		if !ok {
+			items = types.Array{v}
+		}
+
+		for _, item := range items {
+			s, err := types.StringOf(ctx.Convert(item))
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, s)
+		}
+	}
+
+	return out, nil
+}
+
+func jwtVerify(ctx *context.Context, in context.Valuer, keys []context.Valuer, allowed []string) ([]context.Valuer, error) {
+	t, err := tokenOf(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	alg := t.algorithm(ctx)
+	if !supported(alg) {
+		return nil, errors.WithStack(&UnsupportedAlgorithmError{Algorithm: alg})
+	}
+
+	if allowed != nil {
+		found := false
+		for _, a := range allowed {
+			if a == alg {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, errors.WithStack(&DisallowedAlgorithmError{Algorithm: alg, Allowed: allowed})
+		}
+	}
+
+	var out []context.Valuer
+	for _, key := range keys {
+		kv, err := key.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		k, err := keyOf(ctx, alg, kv)
+		if err != nil {
+			return nil, err
+		}
+
+		ok, err := verify(t, alg, k)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, context.NewConstValuer(ok))
+	}
+
+	return out, nil
+}
+
+// JWTVerify reports whether the signature of the input token is valid for the
+// given key. The algorithm is taken from the token header; tokens using
+// "none" or another unsupported algorithm are an error rather than invalid.
+func JWTVerify(ctx *context.Context, in context.Valuer, keys []context.Valuer) ([]context.Valuer, error) {
+	return jwtVerify(ctx, in, keys, nil)
+}
+
+// JWTVerifyWithAlgorithm is JWTVerify restricted to the given algorithm or
+// array of algorithms. Tokens with any other algorithm are an error, so a
+// token cannot choose how its key is used.
+func JWTVerifyWithAlgorithm(ctx *context.Context, in context.Valuer, keys []context.Valuer, algs []context.Valuer) ([]context.Valuer, error) {
+	allowed, err := algorithmsOf(ctx, algs)
+	if err != nil {
+		return nil, err
+	}
+
+	return jwtVerify(ctx, in, keys, allowed)
+}
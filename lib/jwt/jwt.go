@@ -0,0 +1,32 @@
+// Package jwt decodes JSON Web Tokens and verifies their signatures.
+//
+// A decoded token is an object with the keys header, claims and signature.
+// The registered time claims exp, iat and nbf are converted to times when they
+// are whole numbers of seconds between the years 1 and 9999, and are left as
+// numbers otherwise. The signature is left in its base64url encoding.
+//
+// Signatures can be verified for the HS256, HS384, HS512, RS256, RS384,
+// RS512, ES256, ES384 and ES512 algorithms. Verification only checks the
+// signature; the time claims are not compared with the current time.
+//
+// The algorithm comes from the token, which the signer controls. Callers that
+// know which algorithms to expect should pass them, as in
+// jwtverify(key; "RS256"), so that a token cannot choose how its key is used.
+// PEM encoded keys are never accepted as HMAC secrets.
+package jwt
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(JWTDecode)
+	ctx.DefineFunction("jwtdecode", fn)
+
+	fn, _ = function.NewFunction(JWTVerify)
+	ctx.DefineFunction("jwtverify", fn)
+
+	fn, _ = function.NewFunction(JWTVerifyWithAlgorithm)
+	ctx.DefineFunction("jwtverify", fn)
+}
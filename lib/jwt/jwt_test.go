@@ -0,0 +1,282 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const hs256Token = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+	"eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIiwiaWF0IjoxNTE2MjM5MDIyfQ." +
+	"SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c"
+
+func newContext() *context.Context {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+	return ctx
+}
+
+func verifyOne(t *testing.T, ctx *context.Context, token string, key interface{}) (bool, error) {
+	l, err := JWTVerify(ctx, context.NewConstValuer(types.Str(token)), []context.Valuer{context.NewConstValuer(key)})
+	if err != nil {
+		return false, err
+	}
+
+	v, err := l[0].Value(ctx)
+	assert.NoError(t, err)
+
+	return v.(bool), nil
+}
+
+// sign builds a token with the given algorithm from a signing function over
+// the digest of the signing input.
+func sign(alg string, h crypto.Hash, fn func(digest []byte) []byte) string {
+	enc := base64.RawURLEncoding
+	input := enc.EncodeToString([]byte(`{"alg":"`+alg+`"}`)) + "." + enc.EncodeToString([]byte(`{"sub":"x","exp":1700000000.5}`))
+
+	d := h.New()
+	d.Write([]byte(input))
+
+	return input + "." + enc.EncodeToString(fn(d.Sum(nil)))
+}
+
+func pemOf(t *testing.T, pub crypto.PublicKey) types.Str {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	assert.NoError(t, err)
+
+	return types.Str(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestDecode(t *testing.T) {
+	ctx := newContext()
+
+	l, err := JWTDecode(ctx, context.NewConstValuer(types.Str("Bearer "+hs256Token)))
+	assert.NoError(t, err)
+
+	v, err := l[0].Value(ctx)
+	assert.NoError(t, err)
+
+	o := v.(*types.Object)
+	assert.Equal(t, []string{"header", "claims", "signature"}, o.Keys())
+
+	claims, _ := o.Get("claims")
+	assert.Equal(t, []string{"sub", "name", "iat"}, claims.(*types.Object).Keys())
+
+	iat, _ := claims.(*types.Object).Get("iat")
+	assert.Equal(t, types.Time{Time: time.Date(2018, 1, 18, 1, 30, 22, 0, time.UTC)}, iat)
+
+	for _, s := range []string{"a.b", "!!.e30.", "e30.WzFd.", "bnVsbA.e30."} {
+		_, err := JWTDecode(ctx, context.NewConstValuer(types.Str(s)))
+		assert.Error(t, err, s)
+	}
+}
+
+func TestDecodeTimeClaims(t *testing.T) {
+	ctx := newContext()
+
+	enc := base64.RawURLEncoding
+	claims := `{"exp":1e300,"iat":1516239022.5,"nbf":-1e20,"auth_time":1}`
+	token := enc.EncodeToString([]byte(`{"alg":"none"}`)) + "." + enc.EncodeToString([]byte(claims)) + "."
+
+	l, err := JWTDecode(ctx, context.NewConstValuer(types.Str(token)))
+	assert.NoError(t, err)
+
+	v, err := l[0].Value(ctx)
+	assert.NoError(t, err)
+
+	// Claims that are out of range or not integers are left as numbers.
+	c, _ := v.(*types.Object).Get("claims")
+	for _, name := range []string{"exp", "iat", "nbf"} {
+		claim, _ := c.(*types.Object).Get(name)
+		_, ok := ctx.Convert(claim).(types.Time)
+		assert.False(t, ok, name)
+	}
+
+	b, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, claims, string(b))
+}
+
+func TestVerifyHMAC(t *testing.T) {
+	ctx := newContext()
+
+	ok, err := verifyOne(t, ctx, hs256Token, types.Str("your-256-bit-secret"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = verifyOne(t, ctx, hs256Token, types.Str("wrong"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	jwk := types.NewObject()
+	jwk.Set("kty", "oct")
+	jwk.Set("k", base64.RawURLEncoding.EncodeToString([]byte("your-256-bit-secret")))
+
+	ok, err = verifyOne(t, ctx, hs256Token, jwk)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	none := "eyJhbGciOiJub25lIn0.e30."
+	_, err = verifyOne(t, ctx, none, types.Str("x"))
+	assert.Error(t, err)
+}
+
+func TestVerifyRSA(t *testing.T) {
+	ctx := newContext()
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	token := sign("RS384", crypto.SHA384, func(digest []byte) []byte {
+		sig, err := rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA384, digest)
+		assert.NoError(t, err)
+		return sig
+	})
+
+	ok, err := verifyOne(t, ctx, token, pemOf(t, &priv.PublicKey))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	jwk := types.NewObject()
+	jwk.Set("kty", "RSA")
+	jwk.Set("n", base64.RawURLEncoding.EncodeToString(priv.N.Bytes()))
+	jwk.Set("e", base64.RawURLEncoding.EncodeToString(big.NewInt(int64(priv.E)).Bytes()))
+
+	ok, err = verifyOne(t, ctx, token, jwk)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	ok, err = verifyOne(t, ctx, token, pemOf(t, &other.PublicKey))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = verifyOne(t, ctx, token, types.Str("not a key"))
+	assert.Error(t, err)
+}
+
+func TestVerifyECDSA(t *testing.T) {
+	ctx := newContext()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	token := sign("ES256", crypto.SHA256, func(digest []byte) []byte {
+		r, s, err := ecdsa.Sign(rand.Reader, priv, digest)
+		assert.NoError(t, err)
+
+		sig := make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+		return sig
+	})
+
+	ok, err := verifyOne(t, ctx, token, pemOf(t, &priv.PublicKey))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Flip a bit in the last byte of s.
+	last := "A"
+	if token[len(token)-2:len(token)-1] == "A" {
+		last = "Q"
+	}
+
+	tampered := token[:len(token)-2] + last + token[len(token)-1:]
+	ok, err = verifyOne(t, ctx, tampered, pemOf(t, &priv.PublicKey))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+
+	_, err = verifyOne(t, ctx, token, pemOf(t, &p384.PublicKey))
+	assert.Error(t, err)
+}
+
+func TestVerifyAlgorithmConfusion(t *testing.T) {
+	ctx := newContext()
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	pub := pemOf(t, &priv.PublicKey)
+
+	// A token forged by using the RSA public key as an HMAC secret.
+	enc := base64.RawURLEncoding
+	input := enc.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." + enc.EncodeToString([]byte(`{"sub":"admin"}`))
+
+	mac := hmac.New(sha256.New, []byte(pub))
+	mac.Write([]byte(input))
+	forged := input + "." + enc.EncodeToString(mac.Sum(nil))
+
+	_, err = verifyOne(t, ctx, forged, pub)
+	assert.IsType(t, &InvalidKeyError{}, errors.Cause(err))
+
+	_, err = verifyOne(t, ctx, forged, types.Bytes(pub))
+	assert.IsType(t, &InvalidKeyError{}, errors.Cause(err))
+
+	jwk := types.NewObject()
+	jwk.Set("kty", "RSA")
+	jwk.Set("n", enc.EncodeToString(priv.N.Bytes()))
+	jwk.Set("e", enc.EncodeToString(big.NewInt(int64(priv.E)).Bytes()))
+
+	_, err = verifyOne(t, ctx, forged, jwk)
+	assert.IsType(t, &InvalidKeyError{}, errors.Cause(err))
+
+	// The caller can fix the algorithm.
+	token := sign("RS256", crypto.SHA256, func(digest []byte) []byte {
+		sig, err := rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest)
+		assert.NoError(t, err)
+		return sig
+	})
+
+	verifyWith := func(token string, key interface{}, algs interface{}) (interface{}, error) {
+		l, err := JWTVerifyWithAlgorithm(ctx, context.NewConstValuer(types.Str(token)), []context.Valuer{context.NewConstValuer(key)}, []context.Valuer{context.NewConstValuer(algs)})
+		if err != nil {
+			return nil, err
+		}
+
+		return l[0].Value(ctx)
+	}
+
+	v, err := verifyWith(token, pub, types.Str("RS256"))
+	assert.NoError(t, err)
+	assert.Equal(t, true, v)
+
+	v, err = verifyWith(token, pub, types.Array{types.Str("RS256"), types.Str("ES256")})
+	assert.NoError(t, err)
+	assert.Equal(t, true, v)
+
+	_, err = verifyWith(forged, types.Str("secret"), types.Str("RS256"))
+	assert.IsType(t, &DisallowedAlgorithmError{}, errors.Cause(err))
+
+	// So can the alg member of a JWK.
+	jwk.Set("alg", "RS512")
+
+	_, err = verifyOne(t, ctx, token, jwk)
+	assert.IsType(t, &InvalidKeyError{}, errors.Cause(err))
+
+	jwk.Set("alg", "RS256")
+
+	ok, err := verifyOne(t, ctx, token, jwk)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
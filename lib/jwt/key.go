@@ -0,0 +1,165 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// parsePEMKey parses a PEM encoded public key, RSA public key or
+// certificate.
+func parsePEMKey(alg, s string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "expected a PEM encoded public key"})
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: err.Error()})
+		}
+
+		return key, nil
+	case "RSA PUBLIC KEY":
+		key, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: err.Error()})
+		}
+
+		return key, nil
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: err.Error()})
+		}
+
+		return cert.PublicKey, nil
+	default:
+		return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "unexpected PEM block " + block.Type})
+	}
+}
+
+// parseJWK parses a public key in JSON Web Key form (RFC 7517). Symmetric
+// keys (kty "oct") are returned as bytes.
+func parseJWK(ctx *context.Context, alg string, o *types.Object) (interface{}, error) {
+	member := func(name string) ([]byte, error) {
+		v, _ := o.Get(name)
+		s, ok := ctx.Convert(v).(types.Str)
+		if !ok {
+			return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "JWK is missing " + name})
+		}
+
+		b, err := base64.RawURLEncoding.DecodeString(string(s))
+		if err != nil {
+			return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "JWK " + name + " is not base64url encoded"})
+		}
+
+		return b, nil
+	}
+
+	kty, _ := o.Get("kty")
+	crv, _ := o.Get("crv")
+
+	switch ctx.Convert(kty) {
+	case types.Str("oct"):
+		return member("k")
+	case types.Str("RSA"):
+		n, err := member("n")
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := member("e")
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case types.Str("EC"):
+		var curve elliptic.Curve
+		switch ctx.Convert(crv) {
+		case types.Str("P-256"):
+			curve = elliptic.P256()
+		case types.Str("P-384"):
+			curve = elliptic.P384()
+		case types.Str("P-521"):
+			curve = elliptic.P521()
+		default:
+			return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "unsupported JWK curve"})
+		}
+
+		x, err := member("x")
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := member("y")
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	default:
+		return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "unsupported JWK key type"})
+	}
+}
+
+// secretOf returns an HMAC secret given as a string or bytes. PEM encoded keys
+// are refused: a public key is not secret, so a token signed with it as an
+// HMAC secret must not verify.
+func secretOf(alg string, b []byte) ([]byte, error) {
+	if block, _ := pem.Decode(b); block != nil {
+		return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "expected a shared secret, not a PEM encoded key"})
+	}
+
+	return b, nil
+}
+
+// keyOf interprets a key for the given algorithm. HMAC secrets are strings or
+// bytes used as is; public keys are PEM encoded strings or JWK objects. A JWK
+// that names an algorithm may only be used with that algorithm.
+func keyOf(ctx *context.Context, alg string, v interface{}) (interface{}, error) {
+	hmac := alg[:2] == "HS"
+
+	switch vt := v.(type) {
+	case types.Str:
+		if hmac {
+			return secretOf(alg, []byte(vt))
+		}
+
+		return parsePEMKey(alg, string(vt))
+	case types.Bytes:
+		if hmac {
+			return secretOf(alg, vt)
+		}
+
+		return parsePEMKey(alg, string(vt))
+	case *types.Object:
+		if jwkAlg, ok := vt.Get("alg"); ok && ctx.Convert(jwkAlg) != types.Str(alg) {
+			return nil, errors.WithStack(&InvalidKeyError{Algorithm: alg, Reason: "JWK is for another algorithm"})
+		}
+
+		return parseJWK(ctx, alg, vt)
+	default:
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(types.Str("")),
+				reflect.TypeOf(types.Bytes([]byte{})),
+				reflect.TypeOf(&types.Object{}),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/lib/json"
+	"github.com/reflect/filq/types"
+)
+
+// timeClaims are the registered claims that hold a number of seconds since
+// the Unix epoch.
+var timeClaims = []string{"exp", "iat", "nbf"}
+
+// Time claims are only converted between the years 1 and 9999, the range of
+// times that can be formatted.
+const (
+	minClaimSeconds = -62135596800
+	maxClaimSeconds = 253402300799
+)
+
+// timeOfClaim converts a time claim to a time. Claims that are not integers
+// or are out of range are left as they are.
+func timeOfClaim(v interface{}) (time.Time, bool) {
+	r, ok := types.ToRat(v)
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return time.Time{}, false
+	}
+
+	secs := r.Num().Int64()
+	if secs < minClaimSeconds || secs > maxClaimSeconds {
+		return time.Time{}, false
+	}
+
+	return time.Unix(secs, 0).UTC(), true
+}
+
+type token struct {
+	header, claims *types.Object
+	signingInput   string
+	signature      string
+}
+
+func (t *token) algorithm(ctx *context.Context) string {
+	alg, _ := t.header.Get("alg")
+	if s, ok := ctx.Convert(alg).(types.Str); ok {
+		return string(s)
+	}
+
+	return ""
+}
+
+// decodeSegment decodes a base64url encoded JSON object.
+func decodeSegment(ctx *context.Context, name, s string) (*types.Object, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.WithStack(&MalformedTokenError{Reason: name + " is not base64url encoded"})
+	}
+
+	vr, err := json.NewValuer(types.Bytes(b))
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := vr.Value(ctx)
+	if err != nil {
+		return nil, errors.WithStack(&MalformedTokenError{Reason: name + " is not valid JSON"})
+	}
+
+	o, ok := v.(*types.Object)
+	if !ok {
+		return nil, errors.WithStack(&MalformedTokenError{Reason: name + " is not a JSON object"})
+	}
+
+	return o, nil
+}
+
+func parseToken(ctx *context.Context, s string) (*token, error) {
+	s = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(s), "Bearer "))
+
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return nil, errors.WithStack(&MalformedTokenError{Reason: "expected three dot-separated segments"})
+	}
+
+	header, err := decodeSegment(ctx, "header", parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := decodeSegment(ctx, "claims", parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range timeClaims {
+		v, ok := claims.Get(name)
+		if !ok {
+			continue
+		}
+
+		if t, ok := timeOfClaim(ctx.Convert(v)); ok {
+			claims.Set(name, types.Time{Time: t})
+		}
+	}
+
+	return &token{
+		header:       header,
+		claims:       claims,
+		signingInput: parts[0] + "." + parts[1],
+		signature:    parts[2],
+	}, nil
+}
+
+func tokenOf(ctx *context.Context, in context.Valuer) (*token, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseToken(ctx, s)
+}
@@ -0,0 +1,29 @@
+package msgpack
+
+import (
+	"fmt"
+)
+
+type InvalidFormatError struct {
+	Format byte
+}
+
+func (e *InvalidFormatError) Error() string {
+	return fmt.Sprintf("invalid MessagePack format byte 0x%02x", e.Format)
+}
+
+type InvalidTimestampError struct {
+	Length int
+}
+
+func (e *InvalidTimestampError) Error() string {
+	return fmt.Sprintf("invalid MessagePack timestamp of %d bytes", e.Length)
+}
+
+type NestingError struct {
+	Depth int
+}
+
+func (e *NestingError) Error() string {
+	return fmt.Sprintf("values nested more than %d levels deep", e.Depth)
+}
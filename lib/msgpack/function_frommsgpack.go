@@ -0,0 +1,261 @@
+package msgpack
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// maxDepth limits the nesting of arrays and maps so that malicious input
+// cannot exhaust the stack.
+const maxDepth = 1000
+
+// lengthSizes maps format bytes to the size of the length, or value, that
+// follows them.
+var lengthSizes = map[byte]int{
+	0xc4: 1, 0xc5: 2, 0xc6: 4,
+	0xc7: 1, 0xc8: 2, 0xc9: 4,
+	0xcc: 1, 0xcd: 2, 0xce: 4, 0xcf: 8,
+	0xd0: 1, 0xd1: 2, 0xd2: 4, 0xd3: 8,
+	0xd9: 1, 0xda: 2, 0xdb: 4,
+	0xdc: 2, 0xdd: 4,
+	0xde: 2, 0xdf: 4,
+}
+
+type decoder struct {
+	r *bufio.Reader
+}
+
+func (d *decoder) uint(n int) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(d.r, b[8-n:]); err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// bytes reads n bytes without allocating all of them up front, since the
+// length comes from untrusted input.
+func (d *decoder) bytes(n uint64) ([]byte, error) {
+	var b bytes.Buffer
+	if _, err := io.CopyN(&b, d.r, int64(n)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+func (d *decoder) array(n uint64, depth int) (interface{}, error) {
+	a := []interface{}{}
+	for i := uint64(0); i < n; i++ {
+		v, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		a = append(a, v)
+	}
+
+	return a, nil
+}
+
+func (d *decoder) mapping(n uint64, depth int) (interface{}, error) {
+	o := types.NewObject()
+	for i := uint64(0); i < n; i++ {
+		kv, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := kv.(string)
+		if !ok {
+			b, err := json.Marshal(types.SortKeys(kv))
+			if err != nil {
+				return nil, err
+			}
+
+			key = string(b)
+		}
+
+		v, err := d.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		o.Set(key, v)
+	}
+
+	return o, nil
+}
+
+func (d *decoder) ext(n uint64) (interface{}, error) {
+	tb, err := d.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := d.bytes(n)
+	if err != nil {
+		return nil, err
+	}
+
+	typ := int8(tb)
+	if typ != timestampType {
+		o := types.NewObject()
+		o.Set("type", int64(typ))
+		o.Set("data", data)
+		return o, nil
+	}
+
+	switch len(data) {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), nil
+	case 8:
+		v := binary.BigEndian.Uint64(data)
+		return time.Unix(int64(v&(1<<34-1)), int64(v>>34)).UTC(), nil
+	case 12:
+		nsec := binary.BigEndian.Uint32(data)
+		sec := int64(binary.BigEndian.Uint64(data[4:]))
+		return time.Unix(sec, int64(nsec)).UTC(), nil
+	default:
+		return nil, &InvalidTimestampError{Length: len(data)}
+	}
+}
+
+func (d *decoder) value(depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, &NestingError{Depth: maxDepth}
+	}
+
+	f, err := d.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var n uint64
+	if size, ok := lengthSizes[f]; ok {
+		if n, err = d.uint(size); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case f <= 0x7f:
+		return int64(f), nil
+	case f <= 0x8f:
+		return d.mapping(uint64(f&0x0f), depth)
+	case f <= 0x9f:
+		return d.array(uint64(f&0x0f), depth)
+	case f <= 0xbf:
+		b, err := d.bytes(uint64(f & 0x1f))
+		return string(b), err
+	case f >= 0xe0:
+		return int64(int8(f)), nil
+	}
+
+	switch f {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		return d.bytes(n)
+	case 0xc7, 0xc8, 0xc9:
+		return d.ext(n)
+	case 0xca:
+		bits, err := d.uint(4)
+		return float64(math.Float32frombits(uint32(bits))), err
+	case 0xcb:
+		bits, err := d.uint(8)
+		return math.Float64frombits(bits), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		if n > math.MaxInt64 {
+			return json.Number(strconv.FormatUint(n, 10)), nil
+		}
+
+		return int64(n), nil
+	case 0xd0:
+		return int64(int8(n)), nil
+	case 0xd1:
+		return int64(int16(n)), nil
+	case 0xd2:
+		return int64(int32(n)), nil
+	case 0xd3:
+		return int64(n), nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.ext(1 << (f - 0xd4))
+	case 0xd9, 0xda, 0xdb:
+		b, err := d.bytes(n)
+		return string(b), err
+	case 0xdc, 0xdd:
+		return d.array(n, depth)
+	case 0xde, 0xdf:
+		return d.mapping(n, depth)
+	default:
+		return nil, &InvalidFormatError{Format: f}
+	}
+}
+
+// decode reads every value in a stream.
+func decode(r io.Reader) ([]interface{}, error) {
+	d := &decoder{r: bufio.NewReader(r)}
+
+	var out []interface{}
+	for {
+		if _, err := d.r.Peek(1); err == io.EOF {
+			return out, nil
+		}
+
+		v, err := d.value(0)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		out = append(out, v)
+	}
+}
+
+// FromMsgpack decodes a stream of MessagePack values, producing one output
+// for each.
+func FromMsgpack(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := types.ReaderOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := decode(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing MessagePack")
+	}
+
+	out := make([]context.Valuer, len(values))
+	for i, value := range values {
+		out[i] = context.NewConstValuer(value)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,202 @@
+package msgpack
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+type encoder struct {
+	b bytes.Buffer
+}
+
+func (e *encoder) uint(f byte, n int, v uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+
+	e.b.WriteByte(f)
+	e.b.Write(b[8-n:])
+}
+
+// length writes a length using the smallest of the three given formats, which
+// take 1, 2 and 4 byte lengths respectively. A zero format is skipped.
+func (e *encoder) length(formats [3]byte, n int) {
+	switch {
+	case n <= math.MaxUint8 && formats[0] != 0:
+		e.uint(formats[0], 1, uint64(n))
+	case n <= math.MaxUint16:
+		e.uint(formats[1], 2, uint64(n))
+	default:
+		e.uint(formats[2], 4, uint64(n))
+	}
+}
+
+func (e *encoder) int(i int64) {
+	switch {
+	case i >= 0 && i <= 0x7f:
+		e.b.WriteByte(byte(i))
+	case i < 0 && i >= -32:
+		e.b.WriteByte(byte(int8(i)))
+	case i >= 0:
+		e.uint64(uint64(i))
+	case i >= math.MinInt8:
+		e.uint(0xd0, 1, uint64(i))
+	case i >= math.MinInt16:
+		e.uint(0xd1, 2, uint64(i))
+	case i >= math.MinInt32:
+		e.uint(0xd2, 4, uint64(i))
+	default:
+		e.uint(0xd3, 8, uint64(i))
+	}
+}
+
+func (e *encoder) uint64(u uint64) {
+	switch {
+	case u <= math.MaxUint8:
+		e.uint(0xcc, 1, u)
+	case u <= math.MaxUint16:
+		e.uint(0xcd, 2, u)
+	case u <= math.MaxUint32:
+		e.uint(0xce, 4, u)
+	default:
+		e.uint(0xcf, 8, u)
+	}
+}
+
+func (e *encoder) float(f float64) {
+	e.uint(0xcb, 8, math.Float64bits(f))
+}
+
+func (e *encoder) str(s string) {
+	if len(s) <= 31 {
+		e.b.WriteByte(0xa0 | byte(len(s)))
+	} else {
+		e.length([3]byte{0xd9, 0xda, 0xdb}, len(s))
+	}
+
+	e.b.WriteString(s)
+}
+
+// timestamp writes a time using the smallest of the three timestamp formats
+// that can hold it.
+func (e *encoder) timestamp(t types.Time) {
+	sec, nsec := t.Unix(), t.Nanosecond()
+
+	switch {
+	case sec >= 0 && sec <= math.MaxUint32 && nsec == 0:
+		e.b.Write([]byte{0xd6, 0xff})
+		binary.Write(&e.b, binary.BigEndian, uint32(sec))
+	case sec >= 0 && sec < 1<<34:
+		e.b.Write([]byte{0xd7, 0xff})
+		binary.Write(&e.b, binary.BigEndian, uint64(nsec)<<34|uint64(sec))
+	default:
+		e.b.Write([]byte{0xc7, 12, 0xff})
+		binary.Write(&e.b, binary.BigEndian, uint32(nsec))
+		binary.Write(&e.b, binary.BigEndian, sec)
+	}
+}
+
+func (e *encoder) value(ctx *context.Context, v interface{}) error {
+	switch vt := ctx.Convert(v).(type) {
+	case nil:
+		e.b.WriteByte(0xc0)
+	case bool:
+		if vt {
+			e.b.WriteByte(0xc3)
+		} else {
+			e.b.WriteByte(0xc2)
+		}
+	case types.Int:
+		e.int(int64(vt))
+	case types.Float:
+		e.float(float64(vt))
+	case types.Number:
+		// Integers beyond int64 may still fit in a uint64.
+		if r, ok := vt.Rat(); ok && r.IsInt() && !r.Num().IsInt64() && r.Num().IsUint64() {
+			e.uint64(r.Num().Uint64())
+		} else {
+			return e.value(ctx, vt.Demote())
+		}
+	case types.Str:
+		e.str(string(vt))
+	case types.Bytes:
+		e.length([3]byte{0xc4, 0xc5, 0xc6}, len(vt))
+		e.b.Write(vt)
+	case types.Time:
+		e.timestamp(vt)
+	case types.Array:
+		if len(vt) <= 15 {
+			e.b.WriteByte(0x90 | byte(len(vt)))
+		} else {
+			e.length([3]byte{0, 0xdc, 0xdd}, len(vt))
+		}
+
+		for _, item := range vt {
+			if err := e.value(ctx, item); err != nil {
+				return err
+			}
+		}
+	case *types.Object:
+		if vt.Len() <= 15 {
+			e.b.WriteByte(0x80 | byte(vt.Len()))
+		} else {
+			e.length([3]byte{0, 0xde, 0xdf}, vt.Len())
+		}
+
+		for _, key := range vt.Keys() {
+			e.str(key)
+
+			value, _ := vt.Get(key)
+			if err := e.value(ctx, value); err != nil {
+				return err
+			}
+		}
+	default:
+		// Anything else is encoded the same way as in JSON.
+		b, err := json.Marshal(vt)
+		if err != nil {
+			return err
+		}
+
+		var jv interface{}
+		if err := json.Unmarshal(b, &jv); err != nil {
+			return err
+		}
+
+		return e.value(ctx, jv)
+	}
+
+	return nil
+}
+
+type toMsgpack struct {
+	v interface{}
+}
+
+func (tm *toMsgpack) Value(ctx *context.Context) (interface{}, error) {
+	v := tm.v
+	if sortKeys, _ := ctx.Option(types.OptionSortKeys).(bool); sortKeys {
+		v = types.SortKeys(v)
+	}
+
+	var e encoder
+	if err := e.value(ctx, v); err != nil {
+		return nil, errors.Wrap(err, "marshaling MessagePack")
+	}
+
+	return types.Bytes(e.b.Bytes()), nil
+}
+
+func ToMsgpack(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{&toMsgpack{v}}, nil
+}
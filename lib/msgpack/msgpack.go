@@ -0,0 +1,23 @@
+// Package msgpack converts between MessagePack and filq values.
+//
+// Strings decode to types.Str and binary data to types.Bytes, and likewise on
+// encoding. Timestamps (extension type -1) decode to times, and other
+// extension types to an object with the keys "type" and "data". Map keys that
+// are not strings are stored as their JSON representation.
+package msgpack
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+// timestampType is the extension type of timestamps.
+const timestampType = -1
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(FromMsgpack)
+	ctx.DefineFunction("frommsgpack", fn)
+
+	fn, _ = function.NewFunction(ToMsgpack)
+	ctx.DefineFunction("tomsgpack", fn)
+}
@@ -0,0 +1,67 @@
+package msgpack
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	conds := []struct {
+		Hex      string
+		Expected interface{}
+	}{
+		{"7f", int64(127)},
+		{"e0", int64(-32)},
+		{"d0c0", int64(-64)},
+		{"cfffffffffffffffff", json.Number("18446744073709551615")},
+		{"cb3ff8000000000000", 1.5},
+		{"a3616263", "abc"},
+		{"c40201ff", []byte{1, 0xff}},
+		{"d6ff5a4a6d00", time.Unix(1514827008, 0).UTC()},
+		{"d7ff000000045a4a6d00", time.Unix(1514827008, 1).UTC()},
+		{"c70cff00000001ffffffffffffffff", time.Unix(-1, 1).UTC()},
+	}
+
+	for _, cond := range conds {
+		b, _ := hex.DecodeString(cond.Hex)
+
+		values, err := decode(bytes.NewReader(b))
+		assert.NoError(t, err, cond.Hex)
+		assert.Equal(t, []interface{}{cond.Expected}, values, cond.Hex)
+	}
+
+	for _, invalid := range []string{"c1", "a361", "d501", "c7030101"} {
+		b, _ := hex.DecodeString(invalid)
+
+		_, err := decode(bytes.NewReader(b))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	o := types.NewObject()
+	o.Set("z", []interface{}{int64(-1), int64(-200), int64(70000), 1.5, "s", []byte{0xff}, nil, true})
+	o.Set("a", time.Unix(1<<35, 5).UTC())
+
+	var e encoder
+	assert.NoError(t, e.value(ctx, o))
+	assert.NoError(t, e.value(ctx, types.Number("18446744073709551615")))
+
+	values, err := decode(&e.b)
+	assert.NoError(t, err)
+	assert.Len(t, values, 2)
+
+	b, err := json.Marshal(values)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"z":[-1,-200,70000,1.5,"s","/w==",null,true],"a":"3058-10-26T03:46:08.000000005Z"},18446744073709551615]`, string(b))
+}
@@ -0,0 +1,73 @@
+package net
+
+import (
+	"net/netip"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// addrOf returns the address represented by an IP or a string. The boolean
+// result is false if the value is neither.
+func addrOf(v interface{}) (netip.Addr, bool, error) {
+	if ip, ok := v.(types.IP); ok {
+		return ip.Addr, true, nil
+	}
+
+	s, err := types.StringOf(v)
+	if err != nil {
+		return netip.Addr{}, false, nil
+	}
+
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, true, errors.WithStack(&InvalidIPError{Input: s})
+	}
+
+	return addr, true, nil
+}
+
+func requireAddrOf(v interface{}) (netip.Addr, error) {
+	addr, ok, err := addrOf(v)
+	if err != nil {
+		return netip.Addr{}, err
+	} else if !ok {
+		return netip.Addr{}, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(types.IP{}),
+				reflect.TypeOf(types.Str("")),
+				reflect.TypeOf(types.Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	return addr, nil
+}
+
+func prefixOf(v interface{}) (netip.Prefix, error) {
+	s, err := types.StringOf(v)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, errors.WithStack(&InvalidCIDRError{Input: s})
+	}
+
+	return p.Masked(), nil
+}
+
+// contains reports whether a range contains an address. IPv4-mapped IPv6
+// addresses match IPv4 ranges.
+func contains(p netip.Prefix, addr netip.Addr) bool {
+	addr = addr.WithZone("")
+	if p.Addr().Is4() {
+		addr = addr.Unmap()
+	}
+
+	return p.Contains(addr)
+}
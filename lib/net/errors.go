@@ -0,0 +1,30 @@
+package net
+
+import (
+	"fmt"
+)
+
+type InvalidIPError struct {
+	Input string
+}
+
+func (e *InvalidIPError) Error() string {
+	return fmt.Sprintf("invalid IP address %q", e.Input)
+}
+
+type InvalidCIDRError struct {
+	Input string
+}
+
+func (e *InvalidCIDRError) Error() string {
+	return fmt.Sprintf("invalid CIDR range %q", e.Input)
+}
+
+type InvalidPrefixLengthError struct {
+	Length int64
+	Bits   int
+}
+
+func (e *InvalidPrefixLengthError) Error() string {
+	return fmt.Sprintf("invalid prefix length %d for a %d-bit address", e.Length, e.Bits)
+}
@@ -0,0 +1,66 @@
+package net
+
+import (
+	"github.com/reflect/filq/context"
+)
+
+// InRange reports whether the input address is in the given CIDR range.
+func InRange(ctx *context.Context, in context.Valuer, cidr []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := requireAddrOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, c := range cidr {
+		cv, err := c.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		p, err := prefixOf(cv)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, context.NewConstValuer(contains(p, addr)))
+	}
+
+	return out, nil
+}
+
+// CIDRContains reports whether the input CIDR range contains the given
+// address.
+func CIDRContains(ctx *context.Context, in context.Valuer, ip []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := prefixOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, i := range ip {
+		iv, err := i.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		addr, err := requireAddrOf(iv)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, context.NewConstValuer(contains(p, addr)))
+	}
+
+	return out, nil
+}
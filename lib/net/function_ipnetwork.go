@@ -0,0 +1,53 @@
+package net
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// IPNetwork returns the CIDR range of the given prefix length that contains
+// the input address, like "10.1.2.0/24".
+func IPNetwork(ctx *context.Context, in context.Valuer, prefixLen []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := requireAddrOf(v)
+	if err != nil {
+		return nil, err
+	}
+	addr = addr.WithZone("")
+
+	var out []context.Valuer
+	for _, pl := range prefixLen {
+		plv, err := pl.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		n, ok := plv.(types.Int)
+		if !ok {
+			return nil, errors.WithStack(&context.UnexpectedTypeError{
+				Wanted: []reflect.Type{reflect.TypeOf(types.Int(0))},
+				Got:    reflect.TypeOf(plv),
+			})
+		}
+
+		if n < 0 || int(n) > addr.BitLen() {
+			return nil, errors.WithStack(&InvalidPrefixLengthError{Length: int64(n), Bits: addr.BitLen()})
+		}
+
+		p, err := addr.Prefix(int(n))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		out = append(out, context.NewConstValuer(types.Str(p.String())))
+	}
+
+	return out, nil
+}
@@ -0,0 +1,44 @@
+package net
+
+import (
+	"net/netip"
+
+	"github.com/reflect/filq/context"
+)
+
+// isIP reports whether the input is an address satisfying the given
+// predicate. Values that are not addresses yield false rather than an error.
+func isIP(ctx *context.Context, in context.Valuer, pred func(addr netip.Addr) bool) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, ok, err := addrOf(v)
+	if err != nil || !ok {
+		return []context.Valuer{context.NewConstValuer(false)}, nil
+	}
+
+	return []context.Valuer{context.NewConstValuer(pred(addr))}, nil
+}
+
+func IsIPv4(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return isIP(ctx, in, func(addr netip.Addr) bool {
+		return addr.Unmap().Is4()
+	})
+}
+
+func IsIPv6(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return isIP(ctx, in, func(addr netip.Addr) bool {
+		return !addr.Unmap().Is4()
+	})
+}
+
+// IPPrivate reports whether an address is in a private range (RFC 1918 for
+// IPv4, RFC 4193 for IPv6), a loopback address or a link-local address.
+func IPPrivate(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return isIP(ctx, in, func(addr netip.Addr) bool {
+		addr = addr.Unmap()
+		return addr.IsPrivate() || addr.IsLoopback() || addr.IsLinkLocalUnicast()
+	})
+}
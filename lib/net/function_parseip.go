@@ -0,0 +1,20 @@
+package net
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func ParseIP(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := requireAddrOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(types.IP{Addr: addr})}, nil
+}
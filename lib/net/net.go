@@ -0,0 +1,34 @@
+// Package net parses IP addresses and matches them against CIDR ranges.
+//
+// Addresses are represented as types.IP values, which order IPv4 addresses
+// before IPv6 addresses and otherwise numerically. Functions that take an
+// address also accept its string form.
+package net
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(CIDRContains)
+	ctx.DefineFunction("cidrcontains", fn)
+
+	fn, _ = function.NewFunction(InRange)
+	ctx.DefineFunction("inrange", fn)
+
+	fn, _ = function.NewFunction(IPNetwork)
+	ctx.DefineFunction("ipnetwork", fn)
+
+	fn, _ = function.NewFunction(IPPrivate)
+	ctx.DefineFunction("ipprivate", fn)
+
+	fn, _ = function.NewFunction(IsIPv4)
+	ctx.DefineFunction("isipv4", fn)
+
+	fn, _ = function.NewFunction(IsIPv6)
+	ctx.DefineFunction("isipv6", fn)
+
+	fn, _ = function.NewFunction(ParseIP)
+	ctx.DefineFunction("parseip", fn)
+}
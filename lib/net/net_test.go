@@ -0,0 +1,107 @@
+package net
+
+import (
+	"testing"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func call(ctx *context.Context, fn func(ctx *context.Context, in context.Valuer, arg []context.Valuer) ([]context.Valuer, error), in, arg interface{}) (interface{}, error) {
+	out, err := fn(ctx, context.NewConstValuer(in), []context.Valuer{context.NewConstValuer(arg)})
+	if err != nil {
+		return nil, err
+	}
+
+	return out[0].Value(ctx)
+}
+
+func TestInRange(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	conds := []struct {
+		IP, CIDR string
+		Expected bool
+	}{
+		{"10.1.2.3", "10.0.0.0/8", true},
+		{"11.1.2.3", "10.0.0.0/8", false},
+		{"192.168.1.77", "192.168.1.64/26", true},
+		{"192.168.1.128", "192.168.1.64/26", false},
+		{"::ffff:10.1.2.3", "10.0.0.0/8", true},
+		{"2001:db8::1", "2001:db8::/32", true},
+		{"2001:db9::1", "2001:db8::/32", false},
+		{"10.1.2.3", "2001:db8::/32", false},
+		{"10.1.2.3", "10.1.2.3/8", true},
+	}
+
+	for _, cond := range conds {
+		v, err := call(ctx, InRange, cond.IP, cond.CIDR)
+		assert.NoError(t, err, cond.IP)
+		assert.Equal(t, cond.Expected, v, "%s in %s", cond.IP, cond.CIDR)
+
+		v, err = call(ctx, CIDRContains, cond.CIDR, cond.IP)
+		assert.NoError(t, err, cond.IP)
+		assert.Equal(t, cond.Expected, v, "%s contains %s", cond.CIDR, cond.IP)
+	}
+
+	_, err := call(ctx, InRange, "10.1.2.3", "10.0.0.0")
+	assert.Error(t, err)
+
+	_, err = call(ctx, InRange, "10.1.2", "10.0.0.0/8")
+	assert.Error(t, err)
+}
+
+func TestIPNetwork(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	v, err := call(ctx, IPNetwork, "10.1.2.3", int64(24))
+	assert.NoError(t, err)
+	assert.Equal(t, types.Str("10.1.2.0/24"), v)
+
+	v, err = call(ctx, IPNetwork, "2001:db8:1:2::5", int64(48))
+	assert.NoError(t, err)
+	assert.Equal(t, types.Str("2001:db8:1::/48"), v)
+
+	_, err = call(ctx, IPNetwork, "10.1.2.3", int64(33))
+	assert.Error(t, err)
+}
+
+func TestPredicates(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	conds := []struct {
+		Value                 interface{}
+		IPv4, IPv6, IPPrivate bool
+	}{
+		{"10.0.0.1", true, false, true},
+		{"172.16.5.4", true, false, true},
+		{"8.8.8.8", true, false, false},
+		{"127.0.0.1", true, false, true},
+		{"::ffff:192.168.0.1", true, false, true},
+		{"fd00::1", false, true, true},
+		{"fe80::1%eth0", false, true, true},
+		{"2001:4860::8888", false, true, false},
+		{"not an address", false, false, false},
+		{int64(42), false, false, false},
+	}
+
+	for _, cond := range conds {
+		in := context.NewConstValuer(cond.Value)
+
+		l, err := IsIPv4(ctx, in)
+		assert.NoError(t, err)
+		assert.Equal(t, []context.Valuer{context.NewConstValuer(cond.IPv4)}, l, "isipv4 %v", cond.Value)
+
+		l, err = IsIPv6(ctx, in)
+		assert.NoError(t, err)
+		assert.Equal(t, []context.Valuer{context.NewConstValuer(cond.IPv6)}, l, "isipv6 %v", cond.Value)
+
+		l, err = IPPrivate(ctx, in)
+		assert.NoError(t, err)
+		assert.Equal(t, []context.Valuer{context.NewConstValuer(cond.IPPrivate)}, l, "ipprivate %v", cond.Value)
+	}
+}
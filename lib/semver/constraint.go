@@ -0,0 +1,211 @@
+package semver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/types"
+)
+
+type comparator func(v types.Semver) bool
+
+// comparatorGroup is a conjunction of comparators.
+type comparatorGroup struct {
+	all []comparator
+
+	// prereleases are the versions with a pre-release named by the
+	// comparators. As with npm, a pre-release only matches a group that names
+	// a pre-release of the same major, minor and patch version, so that "<2"
+	// does not match "2.0.0-beta".
+	prereleases []types.Semver
+}
+
+func (g comparatorGroup) allows(v types.Semver) bool {
+	if len(v.Prerelease) == 0 {
+		return true
+	}
+
+	for _, p := range g.prereleases {
+		if p.Major == v.Major && p.Minor == v.Minor && p.Patch == v.Patch {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g comparatorGroup) match(v types.Semver) bool {
+	if !g.allows(v) {
+		return false
+	}
+
+	for _, cmp := range g.all {
+		if !cmp(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// constraint is a disjunction of comparator groups.
+type constraint []comparatorGroup
+
+func (c constraint) match(v types.Semver) bool {
+	for _, g := range c {
+		if g.match(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func always(v types.Semver) bool { return true }
+func never(v types.Semver) bool  { return false }
+
+func atLeast(lo types.Semver) comparator {
+	return func(v types.Semver) bool { return v.ComparePrecedence(lo) >= 0 }
+}
+
+func below(hi types.Semver) comparator {
+	return func(v types.Semver) bool { return v.ComparePrecedence(hi) < 0 }
+}
+
+func between(lo, hi types.Semver) comparator {
+	return func(v types.Semver) bool {
+		return v.ComparePrecedence(lo) >= 0 && v.ComparePrecedence(hi) < 0
+	}
+}
+
+// next returns the smallest version greater than every version matching the
+// partial version. It must not be called on a full version or a wildcard.
+func (p partial) next() types.Semver {
+	if p.parts == 1 {
+		return types.Semver{Major: p.v.Major + 1}
+	}
+
+	return types.Semver{Major: p.v.Major, Minor: p.v.Minor + 1}
+}
+
+var operators = []string{">=", "<=", "!=", "==", ">", "<", "=", "~", "^"}
+
+func splitOperator(s string) (string, string) {
+	for _, op := range operators {
+		if strings.HasPrefix(s, op) {
+			return op, strings.TrimSpace(s[len(op):])
+		}
+	}
+
+	return "", s
+}
+
+func newComparator(op string, p partial) comparator {
+	v := p.v
+
+	switch op {
+	case "", "=", "==":
+		switch p.parts {
+		case 0:
+			return always
+		case 3:
+			return func(o types.Semver) bool { return o.ComparePrecedence(v) == 0 }
+		default:
+			return between(v, p.next())
+		}
+	case "!=":
+		eq := newComparator("=", p)
+		return func(o types.Semver) bool { return !eq(o) }
+	case ">":
+		switch p.parts {
+		case 0:
+			return never
+		case 3:
+			return func(o types.Semver) bool { return o.ComparePrecedence(v) > 0 }
+		default:
+			return atLeast(p.next())
+		}
+	case ">=":
+		return atLeast(v)
+	case "<":
+		if p.parts == 0 {
+			return never
+		}
+
+		return below(v)
+	case "<=":
+		switch p.parts {
+		case 0:
+			return always
+		case 3:
+			return func(o types.Semver) bool { return o.ComparePrecedence(v) <= 0 }
+		default:
+			return below(p.next())
+		}
+	case "~":
+		switch p.parts {
+		case 0:
+			return always
+		case 1:
+			return between(v, types.Semver{Major: v.Major + 1})
+		default:
+			return between(v, types.Semver{Major: v.Major, Minor: v.Minor + 1})
+		}
+	case "^":
+		switch {
+		case p.parts == 0:
+			return always
+		case v.Major > 0 || p.parts == 1:
+			return between(v, types.Semver{Major: v.Major + 1})
+		case v.Minor > 0 || p.parts == 2:
+			return between(v, types.Semver{Minor: v.Minor + 1})
+		default:
+			return between(v, types.Semver{Patch: v.Patch + 1})
+		}
+	}
+
+	panic(fmt.Errorf("version operator %q not implemented", op))
+}
+
+// parseConstraint parses a constraint like ">=1.2, <2 || ^3". Comparators in
+// a group may be separated by commas or spaces.
+func parseConstraint(s string) (constraint, error) {
+	var c constraint
+	for _, group := range strings.Split(s, "||") {
+		fields := strings.Fields(strings.Replace(group, ",", " ", -1))
+
+		var g comparatorGroup
+		for i := 0; i < len(fields); i++ {
+			op, rest := splitOperator(fields[i])
+
+			// Allow a space between an operator and its version.
+			if op != "" && rest == "" {
+				if i+1 >= len(fields) {
+					return nil, errors.WithStack(&InvalidConstraintError{Input: s, Reason: "operator " + op + " has no version"})
+				}
+
+				i++
+				rest = fields[i]
+			}
+
+			p, ok := parsePartial(rest)
+			if !ok {
+				return nil, errors.WithStack(&InvalidConstraintError{Input: s, Reason: "invalid version " + rest})
+			}
+
+			g.all = append(g.all, newComparator(op, p))
+			if len(p.v.Prerelease) > 0 {
+				g.prereleases = append(g.prereleases, p.v)
+			}
+		}
+
+		if len(g.all) == 0 {
+			g.all = append(g.all, always)
+		}
+
+		c = append(c, g)
+	}
+
+	return c, nil
+}
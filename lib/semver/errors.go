@@ -0,0 +1,32 @@
+package semver
+
+import (
+	"fmt"
+
+	"github.com/reflect/filq/types"
+)
+
+type InvalidVersionError struct {
+	Input string
+}
+
+func (e *InvalidVersionError) Error() string {
+	return fmt.Sprintf("invalid semantic version %q", e.Input)
+}
+
+type InvalidConstraintError struct {
+	Input  string
+	Reason string
+}
+
+func (e *InvalidConstraintError) Error() string {
+	return fmt.Sprintf("invalid version constraint %q: %s", e.Input, e.Reason)
+}
+
+type VersionRangeError struct {
+	Version types.Semver
+}
+
+func (e *VersionRangeError) Error() string {
+	return fmt.Sprintf("semantic version %s cannot be bumped: version out of range", e.Version)
+}
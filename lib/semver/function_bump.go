@@ -0,0 +1,68 @@
+package semver
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// bump applies fn to the input version. Pre-release identifiers and build
+// metadata are always dropped from the result.
+func bump(ctx *context.Context, in context.Valuer, fn func(v types.Semver) types.Semver) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sv, err := versionOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := fn(sv)
+	out.Prerelease, out.Build = nil, nil
+
+	if out.Major > math.MaxInt64 || out.Minor > math.MaxInt64 || out.Patch > math.MaxInt64 {
+		return nil, errors.WithStack(&VersionRangeError{Version: sv})
+	}
+
+	return []context.Valuer{context.NewConstValuer(out)}, nil
+}
+
+// BumpMajor returns the next major version. A pre-release of a major version,
+// like 2.0.0-rc.1, is bumped to its release.
+func BumpMajor(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return bump(ctx, in, func(v types.Semver) types.Semver {
+		if len(v.Prerelease) > 0 && v.Minor == 0 && v.Patch == 0 {
+			return v
+		}
+
+		return types.Semver{Major: v.Major + 1}
+	})
+}
+
+// BumpMinor returns the next minor version. A pre-release of a minor version,
+// like 1.3.0-rc.1, is bumped to its release.
+func BumpMinor(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return bump(ctx, in, func(v types.Semver) types.Semver {
+		if len(v.Prerelease) > 0 && v.Patch == 0 {
+			return v
+		}
+
+		return types.Semver{Major: v.Major, Minor: v.Minor + 1}
+	})
+}
+
+// BumpPatch returns the next patch version. A pre-release is bumped to its
+// release.
+func BumpPatch(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return bump(ctx, in, func(v types.Semver) types.Semver {
+		if len(v.Prerelease) > 0 {
+			return v
+		}
+
+		return types.Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	})
+}
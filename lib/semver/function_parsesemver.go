@@ -0,0 +1,19 @@
+package semver
+
+import (
+	"github.com/reflect/filq/context"
+)
+
+func ParseSemver(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sv, err := versionOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(sv)}, nil
+}
@@ -0,0 +1,41 @@
+package semver
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// Satisfies reports whether the input version matches the given constraint.
+func Satisfies(ctx *context.Context, in context.Valuer, constraints []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sv, err := versionOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, constraint := range constraints {
+		cv, err := constraint.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := types.StringOf(cv)
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := parseConstraint(s)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, context.NewConstValuer(c.match(sv)))
+	}
+
+	return out, nil
+}
@@ -0,0 +1,160 @@
+package semver
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// partial is a version that may be missing trailing components. Parts is
+// the number of components given, from 0 for a wildcard to 3 for a full
+// version.
+type partial struct {
+	v     types.Semver
+	parts int
+}
+
+func isWildcard(s string) bool {
+	return s == "x" || s == "X" || s == "*"
+}
+
+// parseNumber parses a version component. Components are limited to the range
+// of an int64, so that they can be selected as integers and incremented
+// without wrapping.
+func parseNumber(s string) (uint64, bool) {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return 0, false
+	}
+
+	n, err := strconv.ParseUint(s, 10, 63)
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+func parseIdentifiers(s string, numeric bool) ([]string, bool) {
+	ids := strings.Split(s, ".")
+	for _, id := range ids {
+		if id == "" {
+			return nil, false
+		}
+
+		digits := true
+		for _, c := range id {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				digits = false
+			default:
+				return nil, false
+			}
+		}
+
+		// Numeric pre-release identifiers must not have leading zeros.
+		if numeric && digits && len(id) > 1 && id[0] == '0' {
+			return nil, false
+		}
+	}
+
+	return ids, true
+}
+
+// parsePartial parses a possibly incomplete version. Missing and wildcard
+// components end the version; a pre-release or build suffix is only allowed
+// on a full version.
+func parsePartial(s string) (partial, bool) {
+	var p partial
+
+	s = strings.TrimPrefix(s, "v")
+	if s == "" || isWildcard(s) {
+		return p, true
+	}
+
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		build, ok := parseIdentifiers(s[i+1:], false)
+		if !ok {
+			return p, false
+		}
+
+		p.v.Build, s = build, s[:i]
+	}
+
+	core := s
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		pre, ok := parseIdentifiers(s[i+1:], true)
+		if !ok {
+			return p, false
+		}
+
+		p.v.Prerelease, core = pre, s[:i]
+	}
+
+	components := strings.Split(core, ".")
+	if len(components) > 3 {
+		return p, false
+	}
+
+	fields := []*uint64{&p.v.Major, &p.v.Minor, &p.v.Patch}
+	for i, c := range components {
+		if isWildcard(c) {
+			// Anything after a wildcard must also be a wildcard.
+			for _, rest := range components[i+1:] {
+				if !isWildcard(rest) {
+					return p, false
+				}
+			}
+
+			break
+		}
+
+		n, ok := parseNumber(c)
+		if !ok {
+			return p, false
+		}
+
+		*fields[i] = n
+		p.parts++
+	}
+
+	if p.parts < 3 && (len(p.v.Prerelease) > 0 || len(p.v.Build) > 0) {
+		return p, false
+	}
+
+	return p, true
+}
+
+func parseVersion(s string) (types.Semver, error) {
+	p, ok := parsePartial(strings.TrimSpace(s))
+	if !ok || p.parts != 3 {
+		return types.Semver{}, errors.WithStack(&InvalidVersionError{Input: s})
+	}
+
+	return p.v, nil
+}
+
+// versionOf returns the version represented by a types.Semver or a string.
+func versionOf(v interface{}) (types.Semver, error) {
+	switch vt := v.(type) {
+	case types.Semver:
+		return vt, nil
+	case types.Str:
+		return parseVersion(string(vt))
+	case types.Bytes:
+		return parseVersion(string(vt))
+	default:
+		return types.Semver{}, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(types.Semver{}),
+				reflect.TypeOf(types.Str("")),
+				reflect.TypeOf(types.Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Package semver parses semantic versions and matches them against
+// constraints.
+//
+// Versions are represented as types.Semver values, which compare by
+// precedence, so the usual comparison operators and sort work on them.
+// Functions that take a version also accept its string form, optionally
+// prefixed with "v".
+//
+// A constraint is a comma-separated list of comparators that must all match,
+// like ">=1.2, <2". Alternatives are separated by "||". Comparators use the
+// operators =, !=, >, >=, <, <=, ~ (same minor version) and ^ (same leftmost
+// non-zero component). Versions in comparators may be partial ("1.2") or use
+// wildcards ("1.2.x", "*").
+package semver
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(BumpMajor)
+	ctx.DefineFunction("bumpmajor", fn)
+
+	fn, _ = function.NewFunction(BumpMinor)
+	ctx.DefineFunction("bumpminor", fn)
+
+	fn, _ = function.NewFunction(BumpPatch)
+	ctx.DefineFunction("bumppatch", fn)
+
+	fn, _ = function.NewFunction(ParseSemver)
+	ctx.DefineFunction("parsesemver", fn)
+
+	fn, _ = function.NewFunction(Satisfies)
+	ctx.DefineFunction("satisfies", fn)
+}
@@ -0,0 +1,141 @@
+package semver
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	conds := []struct {
+		Input, Expected string
+	}{
+		{"1.2.3", "1.2.3"},
+		{"v1.2.3", "1.2.3"},
+		{"1.0.0-alpha.1+build.5", "1.0.0-alpha.1+build.5"},
+		{"1.0.0-0A.is.legal", "1.0.0-0A.is.legal"},
+		{"1.2", ""},
+		{"01.2.3", ""},
+		{"1.2.3-01", ""},
+		{"1.2.3-", ""},
+		{"1.2.3+b..1", ""},
+		{"=1.2.3", ""},
+		{"9223372036854775807.0.0", "9223372036854775807.0.0"},
+		{"9223372036854775808.0.0", ""},
+		{"18446744073709551615.0.0", ""},
+	}
+
+	for _, cond := range conds {
+		v, err := parseVersion(cond.Input)
+		if cond.Expected == "" {
+			assert.Error(t, err, cond.Input)
+			continue
+		}
+
+		assert.NoError(t, err, cond.Input)
+		assert.Equal(t, cond.Expected, v.String())
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	conds := []struct {
+		Constraint string
+		Matches    []string
+		Misses     []string
+	}{
+		{">=1.2, <2", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0"}},
+		{">= 1.2 < 2", []string{"1.2.0"}, []string{"2.0.0"}},
+		{"1.2", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"}},
+		{"1.2.x", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"}},
+		{"=1.2.3", []string{"1.2.3", "1.2.3+meta"}, []string{"1.2.4"}},
+		{"!=1.2.3", []string{"1.2.4"}, []string{"1.2.3"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.2.2", "1.3.0"}},
+		{"~1", []string{"1.9.0"}, []string{"2.0.0"}},
+		{"^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"^0", []string{"0.9.9"}, []string{"1.0.0"}},
+		{"<1 || >=3", []string{"0.1.0", "3.0.0"}, []string{"1.0.0", "2.9.9"}},
+		{"*", []string{"0.0.0", "9.9.9"}, nil},
+		{">=1.0.0-rc.1", []string{"1.0.0-rc.2", "1.0.0", "1.2.0"}, []string{"1.0.0-beta", "1.2.0-rc.1"}},
+		{">=1.2, <2", nil, []string{"2.0.0-beta", "1.5.0-rc.1"}},
+		{"<2.0.0", nil, []string{"2.0.0-0", "1.0.0-alpha"}},
+		{"^1.2.3-beta.2", []string{"1.2.3-beta.4", "1.2.3", "1.9.0"}, []string{"1.2.3-beta.1", "1.2.4-beta.1", "2.0.0-beta"}},
+		{"*", []string{"1.0.0"}, []string{"1.0.0-rc.1"}},
+		{"<1 || >=2.0.0-rc.1", []string{"2.0.0-rc.2"}, []string{"0.9.0-rc.1"}},
+	}
+
+	for _, cond := range conds {
+		c, err := parseConstraint(cond.Constraint)
+		assert.NoError(t, err, cond.Constraint)
+
+		for _, s := range cond.Matches {
+			v, err := parseVersion(s)
+			assert.NoError(t, err)
+			assert.True(t, c.match(v), "%s satisfies %s", s, cond.Constraint)
+		}
+
+		for _, s := range cond.Misses {
+			v, err := parseVersion(s)
+			assert.NoError(t, err)
+			assert.False(t, c.match(v), "%s does not satisfy %s", s, cond.Constraint)
+		}
+	}
+
+	for _, s := range []string{">=", ">=1.2.3.4", "1.2-rc", "~>1"} {
+		_, err := parseConstraint(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestBump(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	conds := []struct {
+		Version, Major, Minor, Patch string
+	}{
+		{"1.2.3", "2.0.0", "1.3.0", "1.2.4"},
+		{"1.2.3+b", "2.0.0", "1.3.0", "1.2.4"},
+		{"1.2.3-rc.1", "2.0.0", "1.3.0", "1.2.3"},
+		{"1.3.0-rc.1", "2.0.0", "1.3.0", "1.3.0"},
+		{"2.0.0-rc.1", "2.0.0", "2.0.0", "2.0.0"},
+	}
+
+	for _, cond := range conds {
+		in := context.NewConstValuer(types.Str(cond.Version))
+
+		bumps := []struct {
+			Fn       func(ctx *context.Context, in context.Valuer) ([]context.Valuer, error)
+			Expected string
+		}{
+			{BumpMajor, cond.Major},
+			{BumpMinor, cond.Minor},
+			{BumpPatch, cond.Patch},
+		}
+
+		for _, b := range bumps {
+			l, err := b.Fn(ctx, in)
+			assert.NoError(t, err)
+
+			v, err := l[0].Value(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, b.Expected, v.(types.Semver).String(), cond.Version)
+		}
+	}
+
+	_, err := BumpMajor(ctx, context.NewConstValuer(types.Str("9223372036854775807.0.0")))
+	assert.IsType(t, &VersionRangeError{}, errors.Cause(err))
+
+	l, err := BumpMinor(ctx, context.NewConstValuer(types.Str("9223372036854775807.0.0")))
+	assert.NoError(t, err)
+
+	v, err := l[0].Value(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "9223372036854775807.1.0", v.(types.Semver).String())
+}
@@ -0,0 +1,120 @@
+package time
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func floatOf(v interface{}) (float64, bool) {
+	switch vt := v.(type) {
+	case types.Int:
+		return float64(vt), true
+	case types.Float:
+		return float64(vt), true
+	case types.Number:
+		return floatOf(vt.Demote())
+	default:
+		return 0, false
+	}
+}
+
+// fromEpoch converts a number of seconds since the Unix epoch to a time in
+// UTC.
+func fromEpoch(secs float64) time.Time {
+	whole, frac := math.Modf(secs)
+	return time.Unix(int64(whole), int64(frac*1e9)).UTC()
+}
+
+// toEpoch converts a time to a number of seconds since the Unix epoch, which
+// is an integer unless the time has a fractional second.
+func toEpoch(t time.Time) interface{} {
+	if t.Nanosecond() == 0 {
+		return types.Int(t.Unix())
+	}
+
+	return types.Float(float64(t.UnixNano()) / 1e9)
+}
+
+// fromBrokenDown converts a jq "broken down time" array to a time in UTC. Only
+// the first six elements (year, zero-based month, day of month, hours, minutes
+// and seconds) are considered; out-of-range values are normalized.
+func fromBrokenDown(ctx *context.Context, a types.Array) (time.Time, error) {
+	if len(a) < 6 {
+		return time.Time{}, errors.WithStack(&BrokenDownTimeError{Length: len(a)})
+	}
+
+	var fs [6]float64
+	for i := range fs {
+		v := ctx.Convert(a[i])
+
+		f, ok := floatOf(v)
+		if !ok {
+			return time.Time{}, errors.WithStack(&context.UnexpectedTypeError{
+				Wanted: []reflect.Type{
+					reflect.TypeOf(types.Int(0)),
+					reflect.TypeOf(types.Float(0)),
+				},
+				Got: reflect.TypeOf(v),
+			})
+		}
+
+		fs[i] = f
+	}
+
+	secs, frac := math.Modf(fs[5])
+
+	return time.Date(
+		int(fs[0]), time.Month(fs[1]+1), int(fs[2]),
+		int(fs[3]), int(fs[4]), int(secs), int(frac*1e9),
+		time.UTC,
+	), nil
+}
+
+// toBrokenDown converts a time to a jq "broken down time" array.
+func toBrokenDown(t time.Time) types.Array {
+	var secs interface{} = types.Int(t.Second())
+	if t.Nanosecond() != 0 {
+		secs = types.Float(float64(t.Second()) + float64(t.Nanosecond())/1e9)
+	}
+
+	return types.Array{
+		types.Int(t.Year()),
+		types.Int(t.Month() - 1),
+		types.Int(t.Day()),
+		types.Int(t.Hour()),
+		types.Int(t.Minute()),
+		secs,
+		types.Int(t.Weekday()),
+		types.Int(t.YearDay() - 1),
+	}
+}
+
+// timeOf interprets a value as a time. Times are used directly, numbers are
+// treated as seconds since the Unix epoch, and arrays as broken down times.
+func timeOf(ctx *context.Context, v interface{}) (time.Time, error) {
+	switch vt := v.(type) {
+	case types.Time:
+		return vt.Time, nil
+	case types.Array:
+		return fromBrokenDown(ctx, vt)
+	}
+
+	if f, ok := floatOf(v); ok {
+		return fromEpoch(f), nil
+	}
+
+	return time.Time{}, errors.WithStack(&context.UnexpectedTypeError{
+		Wanted: []reflect.Type{
+			reflect.TypeOf(types.Time{}),
+			reflect.TypeOf(types.Int(0)),
+			reflect.TypeOf(types.Float(0)),
+			reflect.TypeOf(types.Array{}),
+		},
+		Got: reflect.TypeOf(v),
+	})
+}
@@ -0,0 +1,108 @@
+package time
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var iso8601DurationUnits = map[byte]time.Duration{
+	'W': 7 * 24 * time.Hour,
+	'D': 24 * time.Hour,
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+}
+
+// parseDuration parses either a Go duration (e.g. 1h30m) or an ISO 8601
+// duration (e.g. PT1H30M). ISO 8601 durations may not use years or months,
+// because their lengths vary.
+func parseDuration(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	return parseISO8601Duration(s)
+}
+
+func parseISO8601Duration(s string) (time.Duration, error) {
+	in := s
+
+	neg := false
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		s = s[1:]
+	} else if strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+
+	if !strings.HasPrefix(s, "P") || len(s) == 1 {
+		return 0, &InvalidDurationError{Duration: in}
+	}
+	s = s[1:]
+
+	var d float64
+	inTime, components := false, 0
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if inTime || len(s) == 1 {
+				return 0, &InvalidDurationError{Duration: in}
+			}
+
+			inTime = true
+			s = s[1:]
+			continue
+		}
+
+		i := strings.IndexFunc(s, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+		if i <= 0 {
+			return 0, &InvalidDurationError{Duration: in}
+		}
+
+		n, err := strconv.ParseFloat(strings.Replace(s[:i], ",", ".", 1), 64)
+		if err != nil {
+			return 0, &InvalidDurationError{Duration: in}
+		}
+
+		designator := s[i]
+		if designator == 'M' && !inTime || designator == 'Y' {
+			return 0, &InvalidDurationError{Duration: in, Reason: "years and months have no fixed length"}
+		}
+
+		unit, ok := iso8601DurationUnits[designator]
+		if !ok || inTime != (designator == 'H' || designator == 'M' || designator == 'S') {
+			return 0, &InvalidDurationError{Duration: in}
+		}
+
+		d += n * float64(unit)
+		components++
+		s = s[i+1:]
+	}
+
+	if components == 0 {
+		return 0, &InvalidDurationError{Duration: in}
+	} else if d >= math.MaxInt64 {
+		return 0, &InvalidDurationError{Duration: in, Reason: "out of range"}
+	}
+
+	if neg {
+		d = -d
+	}
+
+	return time.Duration(d), nil
+}
+
+// durationOfSeconds converts a number of seconds to a duration. The float64
+// nearest to math.MaxInt64 is 2^63, which does not fit, so the bounds are
+// exclusive.
+func durationOfSeconds(secs float64) (time.Duration, error) {
+	ns := secs * float64(time.Second)
+	if math.IsNaN(ns) || ns >= math.MaxInt64 || ns <= math.MinInt64 {
+		return 0, &InvalidDurationError{Duration: strconv.FormatFloat(secs, 'g', -1, 64), Reason: "out of range"}
+	}
+
+	return time.Duration(ns), nil
+}
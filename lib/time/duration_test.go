@@ -0,0 +1,57 @@
+package time
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	conds := []struct {
+		Duration string
+		Expected time.Duration
+	}{
+		{"1h30m", 90 * time.Minute},
+		{"-1.5s", -1500 * time.Millisecond},
+		{"PT1H30M", 90 * time.Minute},
+		{"P1DT12H", 36 * time.Hour},
+		{"P2W", 14 * 24 * time.Hour},
+		{"PT0,5S", 500 * time.Millisecond},
+		{"-PT10S", -10 * time.Second},
+	}
+
+	for _, cond := range conds {
+		d, err := parseDuration(cond.Duration)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, d, cond.Duration)
+	}
+
+	for _, invalid := range []string{"", "P", "PT", "P1H", "PT1D", "1 hour", "P1DT"} {
+		_, err := parseDuration(invalid)
+		assert.EqualError(t, err, `invalid duration "`+invalid+`"`)
+	}
+
+	_, err := parseDuration("P1Y2M")
+	assert.EqualError(t, err, `invalid duration "P1Y2M": years and months have no fixed length`)
+}
+
+func TestDurationRange(t *testing.T) {
+	// 2^63 nanoseconds is the first value past the end of time.Duration.
+	_, err := parseDuration("PT9223372036.854775808S")
+	assert.EqualError(t, err, `invalid duration "PT9223372036.854775808S": out of range`)
+
+	d, err := parseDuration("PT9223372036S")
+	assert.NoError(t, err)
+	assert.Equal(t, 9223372036*time.Second, d)
+
+	d, err = durationOfSeconds(1.5)
+	assert.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	for _, secs := range []float64{9223372036.854775808, -9223372036.854775808, 1e300, math.NaN()} {
+		_, err := durationOfSeconds(secs)
+		assert.Error(t, err, "%v", secs)
+	}
+}
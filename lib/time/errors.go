@@ -0,0 +1,41 @@
+package time
+
+import (
+	"fmt"
+)
+
+type TimeFormatMismatchError struct {
+	Format, Time string
+}
+
+func (e *TimeFormatMismatchError) Error() string {
+	return fmt.Sprintf("time %q does not match format %q", e.Time, e.Format)
+}
+
+type BrokenDownTimeError struct {
+	Length int
+}
+
+func (e *BrokenDownTimeError) Error() string {
+	return fmt.Sprintf("broken down time has %d elements (wanted at least 6)", e.Length)
+}
+
+type InvalidDurationError struct {
+	Duration, Reason string
+}
+
+func (e *InvalidDurationError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("invalid duration %q: %s", e.Duration, e.Reason)
+	}
+
+	return fmt.Sprintf("invalid duration %q", e.Duration)
+}
+
+type AmbiguousTimeError struct {
+	Time string
+}
+
+func (e *AmbiguousTimeError) Error() string {
+	return fmt.Sprintf("time %q is ambiguous (could be read day-first or month-first)", e.Time)
+}
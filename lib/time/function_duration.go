@@ -0,0 +1,51 @@
+package time
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func Duration(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var d time.Duration
+
+	switch vt := v.(type) {
+	case types.Duration:
+		d = vt.Duration
+	case types.Str, types.Bytes:
+		s, _ := types.StringOf(vt)
+
+		d, err = parseDuration(s)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	default:
+		secs, ok := floatOf(v)
+		if !ok {
+			return nil, errors.WithStack(&context.UnexpectedTypeError{
+				Wanted: []reflect.Type{
+					reflect.TypeOf(types.Str("")),
+					reflect.TypeOf(types.Bytes([]byte{})),
+					reflect.TypeOf(types.Int(0)),
+					reflect.TypeOf(types.Float(0)),
+				},
+				Got: reflect.TypeOf(v),
+			})
+		}
+
+		d, err = durationOfSeconds(secs)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return []context.Valuer{context.NewConstValuer(types.Duration{Duration: d})}, nil
+}
@@ -0,0 +1,27 @@
+package time
+
+import (
+	"time"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func FromDate(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := parsePOSIX(iso8601, s, time.UTC)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(toEpoch(t))}, nil
+}
@@ -0,0 +1,19 @@
+package time
+
+import (
+	"github.com/reflect/filq/context"
+)
+
+func GMTime(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := timeOf(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(toBrokenDown(t.UTC()))}, nil
+}
@@ -0,0 +1,32 @@
+package time
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/reflect/xparse/xtime"
+)
+
+func IsTime(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var r bool
+
+	switch vt := v.(type) {
+	case types.Time:
+		r = true
+	case types.Str, types.Bytes:
+		s, _ := types.StringOf(vt)
+
+		if reject, _ := ctx.Option(OptionRejectAmbiguous).(bool); reject {
+			_, err := parseAuto(ctx, s)
+			r = err == nil
+		} else {
+			r = xtime.Root().IsTime(s)
+		}
+	}
+
+	return []context.Valuer{context.NewConstValuer(r)}, nil
+}
@@ -0,0 +1,21 @@
+package time
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func MkTime(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := timeOf(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like jq, the result is always truncated to whole seconds.
+	return []context.Valuer{context.NewConstValuer(types.Int(t.Unix()))}, nil
+}
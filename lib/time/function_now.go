@@ -0,0 +1,27 @@
+package time
+
+import (
+	"time"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+const (
+	// OptionClock is the name of the context option that overrides the
+	// current time. Its value must be a func() time.Time.
+	OptionClock = "time.clock"
+)
+
+func clock(ctx *context.Context) time.Time {
+	if fn, ok := ctx.Option(OptionClock).(func() time.Time); ok {
+		return fn()
+	}
+
+	return time.Now()
+}
+
+func Now(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	t := clock(ctx)
+	return []context.Valuer{context.NewConstValuer(types.Float(float64(t.UnixNano()) / 1e9))}, nil
+}
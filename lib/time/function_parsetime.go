@@ -1,7 +1,8 @@
 package time
 
 import (
-	"reflect"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/reflect/filq/context"
@@ -9,12 +10,76 @@ import (
 	"github.com/reflect/xparse/xtime"
 )
 
+const (
+	// OptionRejectAmbiguous is the name of the context option that causes
+	// automatically detected times to be rejected if they could be read with
+	// either the day or the month first.
+	OptionRejectAmbiguous = "time.rejectambiguous"
+)
+
+// dayFirstTimeTree recognizes the numeric formats known to xtime with the day
+// and the month swapped. xtime itself always reads the month first.
+var dayFirstTimeTree = xtime.Compile(dayFirstFormats(xtime.TimeFormats))
+
+func dayFirstFormats(formats []string) []string {
+	var out []string
+	for _, format := range formats {
+		switch {
+		case strings.HasPrefix(format, "01/02/"):
+			out = append(out, "02/01/"+format[len("01/02/"):])
+		case strings.HasPrefix(format, "1/2/"):
+			out = append(out, "2/1/"+format[len("1/2/"):])
+		}
+	}
+
+	return out
+}
+
+// parseAuto parses a time in any format known to xtime.
+func parseAuto(ctx *context.Context, in string) (time.Time, error) {
+	t, err := xtime.Parse(in)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if reject, _ := ctx.Option(OptionRejectAmbiguous).(bool); reject {
+		if dt, err := dayFirstTimeTree.Parse(in); err == nil && !dt.Equal(t) {
+			return time.Time{}, errors.WithStack(&AmbiguousTimeError{Time: in})
+		}
+	}
+
+	return t, nil
+}
+
+// parseFormat parses a time using either a POSIX format, if it contains any
+// % specifiers, or a Go layout otherwise.
+func parseFormat(format, in string, loc *time.Location) (time.Time, error) {
+	if strings.Contains(format, "%") {
+		return parsePOSIX(format, in, loc)
+	}
+
+	t, err := time.ParseInLocation(format, in, loc)
+	if err != nil {
+		return time.Time{}, &TimeFormatMismatchError{Format: format, Time: in}
+	}
+
+	return t, nil
+}
+
 type parseTime struct {
-	in string
+	in, format string
+	loc        *time.Location
 }
 
 func (pt *parseTime) Value(ctx *context.Context) (interface{}, error) {
-	t, err := xtime.Parse(pt.in)
+	var t time.Time
+	var err error
+
+	if pt.format == "" {
+		t, err = parseAuto(ctx, pt.in)
+	} else {
+		t, err = parseFormat(pt.format, pt.in, pt.loc)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -28,20 +93,60 @@ func ParseTime(ctx *context.Context, in context.Valuer) ([]context.Valuer, error
 		return nil, err
 	}
 
-	var t string
-	if s, ok := v.(types.Str); ok {
-		t = string(s)
-	} else if b, ok := v.(types.Bytes); ok {
-		t = string(b)
-	} else {
-		return nil, errors.WithStack(&context.UnexpectedTypeError{
-			Wanted: []reflect.Type{
-				reflect.TypeOf(types.Str("")),
-				reflect.TypeOf(types.Bytes([]byte{})),
-			},
-			Got: reflect.TypeOf(v),
-		})
+	t, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{&parseTime{in: t}}, nil
+}
+
+func ParseTimeWithFormat(ctx *context.Context, in context.Valuer, formats []context.Valuer) ([]context.Valuer, error) {
+	return ParseTimeWithFormatInZone(ctx, in, formats, []context.Valuer{context.NewConstValuer("UTC")})
+}
+
+func ParseTimeWithFormatInZone(ctx *context.Context, in context.Valuer, formats, zones []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, format := range formats {
+		fv, err := format.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := types.StringOf(fv)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, zone := range zones {
+			zv, err := zone.Value(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			name, err := types.StringOf(zv)
+			if err != nil {
+				return nil, err
+			}
+
+			loc, err := time.LoadLocation(name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "loading time zone %s", name)
+			}
+
+			out = append(out, &parseTime{in: t, format: f, loc: loc})
+		}
 	}
 
-	return []context.Valuer{&parseTime{t}}, nil
+	return out, nil
 }
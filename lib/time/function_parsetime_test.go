@@ -0,0 +1,44 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeAmbiguous(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+
+	tm, err := parseAuto(ctx, "01/02/2024")
+	assert.NoError(t, err)
+	assert.Equal(t, time.January, tm.Month())
+
+	ctx.DefineOption(OptionRejectAmbiguous, true)
+
+	_, err = parseAuto(ctx, "01/02/2024")
+	assert.IsType(t, &AmbiguousTimeError{}, errors.Cause(err))
+
+	// Dates that can only be read one way are still accepted.
+	for _, in := range []string{"01/01/2024", "12/31/2024", "2024-01-02"} {
+		_, err = parseAuto(ctx, in)
+		assert.NoError(t, err, in)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	expected := time.Date(2024, time.February, 1, 10, 0, 0, 0, time.UTC)
+
+	tm, err := parseFormat("%d/%m/%Y %H:%M", "01/02/2024 10:00", time.UTC)
+	assert.NoError(t, err)
+	assert.True(t, expected.Equal(tm))
+
+	tm, err = parseFormat("02/01/2006 15:04", "01/02/2024 10:00", time.UTC)
+	assert.NoError(t, err)
+	assert.True(t, expected.Equal(tm))
+
+	_, err = parseFormat("2006-01-02", "01/02/2024", time.UTC)
+	assert.IsType(t, &TimeFormatMismatchError{}, err)
+}
@@ -0,0 +1,40 @@
+package time
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func StrFTime(ctx *context.Context, in context.Valuer, formats []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := timeOf(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]context.Valuer, len(formats))
+	for i, format := range formats {
+		fv, err := format.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := types.StringOf(fv)
+		if err != nil {
+			return nil, err
+		}
+
+		s, err := formatPOSIX(f, t)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = context.NewConstValuer(types.Str(s))
+	}
+
+	return out, nil
+}
@@ -0,0 +1,42 @@
+package time
+
+import (
+	"time"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func StrPTime(ctx *context.Context, in context.Valuer, formats []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]context.Valuer, len(formats))
+	for i, format := range formats {
+		fv, err := format.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := types.StringOf(fv)
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := parsePOSIX(f, s, time.UTC)
+		if err != nil {
+			return nil, err
+		}
+
+		out[i] = context.NewConstValuer(toBrokenDown(t.UTC()))
+	}
+
+	return out, nil
+}
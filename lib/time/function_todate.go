@@ -0,0 +1,25 @@
+package time
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func ToDate(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := timeOf(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := formatPOSIX(iso8601, t.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(types.Str(s))}, nil
+}
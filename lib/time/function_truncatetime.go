@@ -0,0 +1,88 @@
+package time
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// truncateCalendar truncates a time to the start of a calendar unit in its own
+// time zone. Weeks start on Monday, as in ISO 8601.
+func truncateCalendar(t time.Time, unit string) (time.Time, bool) {
+	y, m, d := t.Date()
+
+	switch unit {
+	case "year":
+		return time.Date(y, time.January, 1, 0, 0, 0, 0, t.Location()), true
+	case "month":
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location()), true
+	case "week":
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location()), true
+	case "day":
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location()), true
+	case "hour":
+		return time.Date(y, m, d, t.Hour(), 0, 0, 0, t.Location()), true
+	case "minute":
+		return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, t.Location()), true
+	case "second":
+		return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, t.Location()), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TruncateTime(ctx *context.Context, in context.Valuer, units []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := timeOf(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]context.Valuer, len(units))
+	for i, unit := range units {
+		uv, err := unit.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var tt time.Time
+
+		switch ut := uv.(type) {
+		case types.Duration:
+			tt = t.Truncate(ut.Duration)
+		case types.Str, types.Bytes:
+			s, _ := types.StringOf(ut)
+
+			var ok bool
+			if tt, ok = truncateCalendar(t, s); !ok {
+				d, err := parseDuration(s)
+				if err != nil {
+					return nil, errors.WithStack(err)
+				}
+
+				tt = t.Truncate(d)
+			}
+		default:
+			return nil, errors.WithStack(&context.UnexpectedTypeError{
+				Wanted: []reflect.Type{
+					reflect.TypeOf(types.Str("")),
+					reflect.TypeOf(types.Bytes([]byte{})),
+					reflect.TypeOf(types.Duration{}),
+				},
+				Got: reflect.TypeOf(uv),
+			})
+		}
+
+		out[i] = context.NewConstValuer(types.Time{Time: tt})
+	}
+
+	return out, nil
+}
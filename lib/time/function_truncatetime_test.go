@@ -0,0 +1,63 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateTime(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	assert.NoError(t, err)
+
+	// The day daylight saving time starts in Berlin.
+	tm := time.Date(2024, time.March, 31, 3, 30, 0, 0, berlin)
+
+	conds := []struct {
+		Unit     interface{}
+		Expected time.Time
+	}{
+		{"year", time.Date(2024, time.January, 1, 0, 0, 0, 0, berlin)},
+		{"month", time.Date(2024, time.March, 1, 0, 0, 0, 0, berlin)},
+		{"week", time.Date(2024, time.March, 25, 0, 0, 0, 0, berlin)},
+		{"day", time.Date(2024, time.March, 31, 0, 0, 0, 0, berlin)},
+		{"hour", time.Date(2024, time.March, 31, 3, 0, 0, 0, berlin)},
+		{"20m", time.Date(2024, time.March, 31, 3, 20, 0, 0, berlin)},
+		{types.Duration{Duration: time.Hour}, time.Date(2024, time.March, 31, 3, 0, 0, 0, berlin)},
+	}
+
+	for _, cond := range conds {
+		vrs, err := TruncateTime(ctx, context.NewConstValuer(types.Time{Time: tm}), []context.Valuer{context.NewConstValuer(cond.Unit)})
+		assert.NoError(t, err)
+		assert.Len(t, vrs, 1)
+
+		v, err := vrs[0].Value(ctx)
+		assert.NoError(t, err)
+		assert.True(t, cond.Expected.Equal(v.(types.Time).Time), "%v: %v", cond.Unit, v)
+		assert.Equal(t, berlin, v.(types.Time).Location())
+	}
+
+	_, err = TruncateTime(ctx, context.NewConstValuer(types.Time{Time: tm}), []context.Valuer{context.NewConstValuer("fortnight")})
+	assert.EqualError(t, err, `invalid duration "fortnight"`)
+}
+
+func TestTZ(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	vrs, err := TZ(ctx, context.NewConstValuer(int64(0)), []context.Valuer{context.NewConstValuer("Asia/Tokyo")})
+	assert.NoError(t, err)
+
+	v, err := vrs[0].Value(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, v.(types.Time).Hour())
+
+	_, err = TZ(ctx, context.NewConstValuer(int64(0)), []context.Valuer{context.NewConstValuer("Mars/Olympus_Mons")})
+	assert.Error(t, err)
+}
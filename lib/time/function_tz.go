@@ -0,0 +1,60 @@
+package time
+
+import (
+	"time"
+	// Embed the time zone database so that zones can be loaded on systems
+	// that do not have one installed.
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func TZ(ctx *context.Context, in context.Valuer, zones []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := timeOf(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]context.Valuer, len(zones))
+	for i, zone := range zones {
+		zv, err := zone.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := types.StringOf(zv)
+		if err != nil {
+			return nil, err
+		}
+
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading time zone %s", name)
+		}
+
+		out[i] = context.NewConstValuer(types.Time{Time: t.In(loc)})
+	}
+
+	return out, nil
+}
+
+func UTC(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := timeOf(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(types.Time{Time: t.UTC()})}, nil
+}
@@ -0,0 +1,82 @@
+package time
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPOSIX(t *testing.T) {
+	tm := time.Date(2015, time.March, 5, 23, 51, 47, 0, time.UTC)
+
+	conds := []struct {
+		Format, Expected string
+	}{
+		{iso8601, "2015-03-05T23:51:47Z"},
+		{"%a %A %b %B %e", "Thu Thursday Mar March  5"},
+		{"%j %U %W %G-W%V-%u %w", "064 09 09 2015-W10-4 4"},
+		{"%I:%M %p %P %l", "11:51 PM pm 11"},
+		{"%s %z %Z %%", "1425599507 +0000 UTC %"},
+		{"%C%y %D %F %T", "2015 03/05/15 2015-03-05 23:51:47"},
+	}
+
+	for _, cond := range conds {
+		s, err := formatPOSIX(cond.Format, tm)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, s)
+	}
+
+	_, err := formatPOSIX("%Q", tm)
+	assert.Error(t, err)
+
+	_, err = formatPOSIX("trailing %", tm)
+	assert.Error(t, err)
+}
+
+func TestParsePOSIX(t *testing.T) {
+	tm, err := parsePOSIX("%d/%m/%Y %H:%M", "5/3/2015 23:51", time.UTC)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2015, time.March, 5, 23, 51, 0, 0, time.UTC), tm)
+
+	_, err = parsePOSIX("%Y-%m-%d", "05/03/2015", time.UTC)
+	assert.EqualError(t, err, `time "05/03/2015" does not match format "%Y-%m-%d"`)
+}
+
+func TestBrokenDownTime(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	tm := time.Date(2015, time.March, 5, 23, 51, 47, 500000000, time.UTC)
+	bd := toBrokenDown(tm)
+	assert.Equal(t, types.Array{
+		types.Int(2015), types.Int(2), types.Int(5),
+		types.Int(23), types.Int(51), types.Float(47.5),
+		types.Int(4), types.Int(63),
+	}, bd)
+
+	rt, err := fromBrokenDown(ctx, bd)
+	assert.NoError(t, err)
+	assert.Equal(t, tm, rt)
+
+	// The month and day of the year are 0-based in broken down times but
+	// 1-based in the time selectors.
+	for sub, expected := range map[string]types.Int{"month": 3, "yday": 64} {
+		sel, err := types.Time{Time: tm}.Select(ctx, []context.Valuer{context.NewConstValuer(sub)})
+		assert.NoError(t, err)
+
+		v, err := sel.Value(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, v, sub)
+	}
+
+	// Out-of-range values are normalized.
+	rt, err = fromBrokenDown(ctx, types.Array{int64(2015), int64(12), int64(1), int64(0), int64(0), int64(0)})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC), rt)
+
+	_, err = fromBrokenDown(ctx, types.Array{int64(2015)})
+	assert.EqualError(t, err, "broken down time has 1 elements (wanted at least 6)")
+}
@@ -6,6 +6,51 @@ import (
 )
 
 func DefineIn(ctx *context.Context) {
-	fn, _ := function.NewFunction(ParseTime)
+	fn, _ := function.NewFunction(Duration)
+	ctx.DefineFunction("duration", fn)
+
+	fn, _ = function.NewFunction(FromDate)
+	ctx.DefineFunction("fromdate", fn)
+
+	fn, _ = function.NewFunction(GMTime)
+	ctx.DefineFunction("gmtime", fn)
+
+	fn, _ = function.NewFunction(IsTime)
+	ctx.DefineFunction("istime", fn)
+
+	fn, _ = function.NewFunction(MkTime)
+	ctx.DefineFunction("mktime", fn)
+
+	fn, _ = function.NewFunction(Now)
+	ctx.DefineFunction("now", fn)
+
+	fn, _ = function.NewFunction(ParseTime)
+	ctx.DefineFunction("parsetime", fn)
+
+	fn, _ = function.NewFunction(ParseTimeWithFormat)
 	ctx.DefineFunction("parsetime", fn)
+
+	fn, _ = function.NewFunction(ParseTimeWithFormatInZone)
+	ctx.DefineFunction("parsetime", fn)
+
+	fn, _ = function.NewFunction(StrFTime)
+	ctx.DefineFunction("strftime", fn)
+
+	fn, _ = function.NewFunction(StrPTime)
+	ctx.DefineFunction("strptime", fn)
+
+	fn, _ = function.NewFunction(ToDate)
+	ctx.DefineFunction("todate", fn)
+
+	fn, _ = function.NewFunction(ToTime)
+	ctx.DefineFunction("totime", fn)
+
+	fn, _ = function.NewFunction(TruncateTime)
+	ctx.DefineFunction("truncate_time", fn)
+
+	fn, _ = function.NewFunction(TZ)
+	ctx.DefineFunction("tz", fn)
+
+	fn, _ = function.NewFunction(UTC)
+	ctx.DefineFunction("utc", fn)
 }
@@ -0,0 +1,26 @@
+package url
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// mapString applies a string function to a string input.
+func mapString(ctx *context.Context, in context.Valuer, fn func(s string) (interface{}, error)) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := fn(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(out)}, nil
+}
@@ -0,0 +1,62 @@
+package url
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// parseQuery parses a query string, keeping the order of its keys.
+func parseQuery(s string) (*types.Object, error) {
+	o := types.NewObject()
+
+	for _, pair := range strings.Split(strings.TrimPrefix(s, "?"), "&") {
+		if pair == "" {
+			continue
+		}
+
+		rawKey, rawValue := pair, ""
+		hasValue := false
+		if i := strings.IndexByte(pair, '='); i >= 0 {
+			rawKey, rawValue, hasValue = pair[:i], pair[i+1:], true
+		}
+
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing query key %q", rawKey)
+		}
+
+		var value interface{}
+		if hasValue {
+			s, err := url.QueryUnescape(rawValue)
+			if err != nil {
+				return nil, errors.Wrapf(err, "parsing query value %q", rawValue)
+			}
+
+			value = s
+		}
+
+		prev, ok := o.Get(key)
+		switch pt := prev.(type) {
+		case []interface{}:
+			o.Set(key, append(pt, value))
+		default:
+			if ok {
+				o.Set(key, []interface{}{pt, value})
+			} else {
+				o.Set(key, value)
+			}
+		}
+	}
+
+	return o, nil
+}
+
+func ParseQuery(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return mapString(ctx, in, func(s string) (interface{}, error) {
+		return parseQuery(s)
+	})
+}
@@ -0,0 +1,75 @@
+package url
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func parseURL(s string) (*types.Object, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	query, err := parseQuery(u.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	o := types.NewObject()
+	o.Set("scheme", u.Scheme)
+
+	var username, password interface{}
+	if u.User != nil {
+		username = u.User.Username()
+		if p, ok := u.User.Password(); ok {
+			password = p
+		}
+	}
+	o.Set("username", username)
+	o.Set("password", password)
+
+	o.Set("host", u.Hostname())
+
+	var port interface{}
+	if p := u.Port(); p != "" {
+		n, err := strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing port %q", p)
+		}
+
+		port = n
+	}
+	o.Set("port", port)
+
+	o.Set("path", u.Path)
+	o.Set("query", query)
+
+	var fragment interface{}
+	if u.Fragment != "" {
+		fragment = u.Fragment
+	}
+	o.Set("fragment", fragment)
+
+	// The path is decoded, which loses the difference between "/" and "%2F".
+	// When the original escaping matters it is kept alongside.
+	if u.RawPath != "" {
+		o.Set("rawpath", u.EscapedPath())
+	}
+
+	if u.Opaque != "" {
+		o.Set("opaque", u.Opaque)
+	}
+
+	return o, nil
+}
+
+func ParseURL(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return mapString(ctx, in, func(s string) (interface{}, error) {
+		return parseURL(s)
+	})
+}
@@ -0,0 +1,68 @@
+package url
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// percentEncode escapes every byte except the unreserved characters of RFC
+// 3986, like jq's @uri.
+func percentEncode(s string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-_.~", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+
+	return b.String()
+}
+
+// PercentEncode escapes a string for use in any part of a URL.
+func PercentEncode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return mapString(ctx, in, func(s string) (interface{}, error) {
+		return types.Str(percentEncode(s)), nil
+	})
+}
+
+// PercentDecode unescapes percent-encoded bytes. Unlike urldecode, "+" is left
+// as is.
+func PercentDecode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return mapString(ctx, in, func(s string) (interface{}, error) {
+		out, err := url.PathUnescape(s)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		return types.Str(out), nil
+	})
+}
+
+// URLEncode escapes a string for use in a query string, encoding spaces as
+// "+".
+func URLEncode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return mapString(ctx, in, func(s string) (interface{}, error) {
+		return types.Str(url.QueryEscape(s)), nil
+	})
+}
+
+// URLDecode unescapes a query string component, decoding "+" as a space.
+func URLDecode(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return mapString(ctx, in, func(s string) (interface{}, error) {
+		out, err := url.QueryUnescape(s)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		return types.Str(out), nil
+	})
+}
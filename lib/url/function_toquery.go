@@ -0,0 +1,66 @@
+package url
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// encodeQuery formats an object as a query string. Arrays are written as
+// repeated keys and null values as keys without a value.
+func encodeQuery(ctx *context.Context, o *types.Object) (string, error) {
+	var pairs []string
+
+	for _, key := range o.Keys() {
+		value, _ := o.Get(key)
+
+		values := []interface{}{value}
+		if a, ok := ctx.Convert(value).(types.Array); ok {
+			values = a
+		}
+
+		for _, v := range values {
+			if ctx.Convert(v) == nil {
+				pairs = append(pairs, url.QueryEscape(key))
+				continue
+			}
+
+			s, err := types.TextOf(ctx.Convert(v))
+			if err != nil {
+				return "", err
+			}
+
+			pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(s))
+		}
+	}
+
+	return strings.Join(pairs, "&"), nil
+}
+
+func ToQuery(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	o, ok := v.(*types.Object)
+	if !ok {
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(&types.Object{}),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	s, err := encodeQuery(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(types.Str(s))}, nil
+}
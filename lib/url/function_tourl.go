@@ -0,0 +1,136 @@
+package url
+
+import (
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// optionalString reads a string from an object. Missing keys and null values
+// are reported as absent.
+func optionalString(ctx *context.Context, o *types.Object, key string) (string, bool, error) {
+	v, _ := o.Get(key)
+	if v = ctx.Convert(v); v == nil {
+		return "", false, nil
+	}
+
+	s, err := types.TextOf(v)
+	if err != nil {
+		return "", false, err
+	}
+
+	return s, true, nil
+}
+
+// buildURL formats an object in the form returned by parseurl. The query may
+// also be given as a string.
+func buildURL(ctx *context.Context, o *types.Object) (string, error) {
+	var u url.URL
+
+	fields := []struct {
+		key    string
+		target *string
+	}{
+		{"scheme", &u.Scheme},
+		{"host", &u.Host},
+		{"path", &u.Path},
+		{"fragment", &u.Fragment},
+		{"opaque", &u.Opaque},
+	}
+
+	for _, field := range fields {
+		s, _, err := optionalString(ctx, o, field.key)
+		if err != nil {
+			return "", err
+		}
+
+		*field.target = s
+	}
+
+	// The raw path is only used while it still decodes to the path, so that
+	// changing the path alone takes effect.
+	if rawPath, ok, err := optionalString(ctx, o, "rawpath"); err != nil {
+		return "", err
+	} else if ok {
+		path, err := url.PathUnescape(rawPath)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+
+		if u.Path == "" {
+			u.Path = path
+		}
+		if u.Path == path {
+			u.RawPath = rawPath
+		}
+	}
+
+	if port, ok, err := optionalString(ctx, o, "port"); err != nil {
+		return "", err
+	} else if ok {
+		u.Host = net.JoinHostPort(u.Host, port)
+	} else if strings.Contains(u.Host, ":") {
+		// IPv6 addresses need brackets even without a port.
+		u.Host = "[" + u.Host + "]"
+	}
+
+	username, hasUsername, err := optionalString(ctx, o, "username")
+	if err != nil {
+		return "", err
+	}
+
+	password, hasPassword, err := optionalString(ctx, o, "password")
+	if err != nil {
+		return "", err
+	}
+
+	if hasPassword {
+		u.User = url.UserPassword(username, password)
+	} else if hasUsername {
+		u.User = url.User(username)
+	}
+
+	query, _ := o.Get("query")
+	switch qt := ctx.Convert(query).(type) {
+	case nil:
+	case *types.Object:
+		if u.RawQuery, err = encodeQuery(ctx, qt); err != nil {
+			return "", err
+		}
+	default:
+		if u.RawQuery, err = types.StringOf(qt); err != nil {
+			return "", err
+		}
+	}
+
+	return u.String(), nil
+}
+
+func ToURL(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	o, ok := v.(*types.Object)
+	if !ok {
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(&types.Object{}),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	s, err := buildURL(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(types.Str(s))}, nil
+}
@@ -0,0 +1,41 @@
+// Package url decomposes and builds URLs and query strings.
+//
+// A URL is represented as an object with the keys scheme, username,
+// password, host, port, path, query and fragment, in that order. Missing
+// credentials, ports and fragments are null. Opaque URLs, like
+// "mailto:someone@example.com", also have an opaque key.
+//
+// A query string is represented as an object whose values are strings, or
+// arrays of strings for repeated keys. Keys without a value map to null.
+package url
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(ParseQuery)
+	ctx.DefineFunction("parsequery", fn)
+
+	fn, _ = function.NewFunction(ParseURL)
+	ctx.DefineFunction("parseurl", fn)
+
+	fn, _ = function.NewFunction(PercentDecode)
+	ctx.DefineFunction("percentdecode", fn)
+
+	fn, _ = function.NewFunction(PercentEncode)
+	ctx.DefineFunction("percentencode", fn)
+
+	fn, _ = function.NewFunction(ToQuery)
+	ctx.DefineFunction("toquery", fn)
+
+	fn, _ = function.NewFunction(ToURL)
+	ctx.DefineFunction("tourl", fn)
+
+	fn, _ = function.NewFunction(URLDecode)
+	ctx.DefineFunction("urldecode", fn)
+
+	fn, _ = function.NewFunction(URLEncode)
+	ctx.DefineFunction("urlencode", fn)
+}
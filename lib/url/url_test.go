@@ -0,0 +1,117 @@
+package url
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURLRoundTrip(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	conds := []struct {
+		URL, Expected string
+	}{
+		{
+			"https://u:p@[::1]:8443/a%20b?x=1&x=2&y=a+b&z#frag",
+			`{"scheme":"https","username":"u","password":"p","host":"::1","port":8443,"path":"/a b","query":{"x":["1","2"],"y":"a b","z":null},"fragment":"frag"}`,
+		},
+		{
+			"http://[fe80::1]/",
+			`{"scheme":"http","username":null,"password":null,"host":"fe80::1","port":null,"path":"/","query":{},"fragment":null}`,
+		},
+		{
+			"http://x/a%2Fb",
+			`{"scheme":"http","username":null,"password":null,"host":"x","port":null,"path":"/a/b","query":{},"fragment":null,"rawpath":"/a%2Fb"}`,
+		},
+		{
+			"mailto:someone@example.com",
+			`{"scheme":"mailto","username":null,"password":null,"host":"","port":null,"path":"","query":{},"fragment":null,"opaque":"someone@example.com"}`,
+		},
+	}
+
+	for _, cond := range conds {
+		o, err := parseURL(cond.URL)
+		assert.NoError(t, err, cond.URL)
+
+		b, err := json.Marshal(o)
+		assert.NoError(t, err, cond.URL)
+		assert.Equal(t, cond.Expected, string(b), cond.URL)
+
+		s, err := buildURL(ctx, o)
+		assert.NoError(t, err, cond.URL)
+		assert.Equal(t, cond.URL, s)
+	}
+}
+
+func TestURLRawPath(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	o, err := parseURL("http://x/a%2Fb")
+	assert.NoError(t, err)
+
+	// A changed path takes precedence over a stale raw path.
+	o.Set("path", "/c")
+
+	s, err := buildURL(ctx, o)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://x/c", s)
+
+	// The raw path alone is enough.
+	o.Delete("path")
+
+	s, err = buildURL(ctx, o)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://x/a%2Fb", s)
+}
+
+func TestQuery(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	o, err := parseQuery("b=2&a=1&b=3&flag&c=x%26y")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "a", "flag", "c"}, o.Keys())
+
+	s, err := encodeQuery(ctx, o)
+	assert.NoError(t, err)
+	assert.Equal(t, "b=2&b=3&a=1&flag&c=x%26y", s)
+
+	_, err = parseQuery("a=%zz")
+	assert.Error(t, err)
+}
+
+func TestQueryScalars(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	// Values that marshal as JSON strings are encoded without the quotes.
+	o := types.NewObject()
+	o.Set("t", types.Time{Time: time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)})
+	o.Set("n", types.Int(1))
+	o.Set("b", true)
+
+	s, err := encodeQuery(ctx, o)
+	assert.NoError(t, err)
+	assert.Equal(t, "t=2024-01-02T03%3A04%3A05Z&n=1&b=true", s)
+
+	u := types.NewObject()
+	u.Set("scheme", "http")
+	u.Set("host", "x")
+	u.Set("port", types.Int(8080))
+	u.Set("query", o)
+
+	s, err = buildURL(ctx, u)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://x:8080?t=2024-01-02T03%3A04%3A05Z&n=1&b=true", s)
+}
+
+func TestPercentEncode(t *testing.T) {
+	assert.Equal(t, "a%20b%2F%26~%C3%A9", percentEncode("a b/&~é"))
+}
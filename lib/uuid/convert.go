@@ -0,0 +1,42 @@
+package uuid
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+// mapString applies a string function to a string input.
+func mapString(ctx *context.Context, in context.Valuer, fn func(s string) (interface{}, error)) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := fn(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(out)}, nil
+}
+
+// isValid reports whether the input is a string that parse accepts. Other
+// values yield false rather than an error.
+func isValid(ctx *context.Context, in context.Valuer, parse func(s string) error) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := types.StringOf(v)
+	if err != nil {
+		return []context.Valuer{context.NewConstValuer(false)}, nil
+	}
+
+	return []context.Valuer{context.NewConstValuer(parse(s) == nil)}, nil
+}
@@ -0,0 +1,39 @@
+package uuid
+
+import (
+	"fmt"
+	"time"
+)
+
+type InvalidUUIDError struct {
+	Input string
+}
+
+func (e *InvalidUUIDError) Error() string {
+	return fmt.Sprintf("invalid UUID %q", e.Input)
+}
+
+type InvalidULIDError struct {
+	Input string
+}
+
+func (e *InvalidULIDError) Error() string {
+	return fmt.Sprintf("invalid ULID %q", e.Input)
+}
+
+type NoTimestampError struct {
+	UUID    string
+	Version int
+}
+
+func (e *NoTimestampError) Error() string {
+	return fmt.Sprintf("UUID %s is version %d and has no timestamp (wanted version 7)", e.UUID, e.Version)
+}
+
+type TimestampRangeError struct {
+	Time time.Time
+}
+
+func (e *TimestampRangeError) Error() string {
+	return fmt.Sprintf("time %s cannot be stored as a 48-bit Unix timestamp in milliseconds", e.Time.Format(time.RFC3339Nano))
+}
@@ -0,0 +1,123 @@
+package uuid
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulid is a 48-bit millisecond timestamp followed by 80 random bits. See
+// https://github.com/ulid/spec.
+type ulid [16]byte
+
+func (u ulid) String() string {
+	// 26 characters of 5 bits each hold 130 bits, so the two high bits of the
+	// first character are always zero.
+	var b [26]byte
+	for i := 25; i >= 0; i-- {
+		bit := 5 * (25 - i)
+
+		var c byte
+		for j := 0; j < 5; j++ {
+			n := bit + j
+			if n >= 128 {
+				break
+			}
+
+			if u[15-n/8]>>uint(n%8)&1 == 1 {
+				c |= 1 << uint(j)
+			}
+		}
+
+		b[i] = crockford[c]
+	}
+
+	return string(b[:])
+}
+
+func parseULID(s string) (ulid, error) {
+	var u ulid
+	if len(s) != 26 {
+		return u, errors.WithStack(&InvalidULIDError{Input: s})
+	}
+
+	for i := 0; i < 26; i++ {
+		c := strings.IndexByte(crockford, strings.ToUpper(s[i : i+1])[0])
+		if c < 0 || (i == 0 && c > 7) {
+			return u, errors.WithStack(&InvalidULIDError{Input: s})
+		}
+
+		bit := 5 * (25 - i)
+		for j := 0; j < 5; j++ {
+			n := bit + j
+			if n < 128 && c>>uint(j)&1 == 1 {
+				u[15-n/8] |= 1 << uint(n%8)
+			}
+		}
+	}
+
+	return u, nil
+}
+
+func newULID(ctx *context.Context) (ulid, error) {
+	var u ulid
+	if err := random(ctx, u[6:]); err != nil {
+		return u, err
+	}
+
+	if err := putMillis(u[:], clock(ctx)); err != nil {
+		return u, err
+	}
+
+	return u, nil
+}
+
+func ULID(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	u, err := newULID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(types.Str(u.String()))}, nil
+}
+
+func IsULID(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return isValid(ctx, in, func(s string) error {
+		_, err := parseULID(s)
+		return err
+	})
+}
+
+// ParseULID returns an object with the canonical form of the input ULID and
+// the time at which it was generated.
+func ParseULID(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return mapString(ctx, in, func(s string) (interface{}, error) {
+		u, err := parseULID(s)
+		if err != nil {
+			return nil, err
+		}
+
+		o := types.NewObject()
+		o.Set("ulid", u.String())
+		o.Set("time", types.Time{Time: millisOf(u[:])})
+
+		return o, nil
+	})
+}
+
+// ULIDTime returns the time at which a ULID was generated, to the
+// millisecond.
+func ULIDTime(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return mapString(ctx, in, func(s string) (interface{}, error) {
+		u, err := parseULID(s)
+		if err != nil {
+			return nil, err
+		}
+
+		return types.Time{Time: millisOf(u[:])}, nil
+	})
+}
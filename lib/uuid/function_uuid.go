@@ -0,0 +1,228 @@
+package uuid
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+type uuid [16]byte
+
+// Namespaces for name-based UUIDs, from RFC 9562, appendix C.
+var namespaces = map[string]uuid{
+	"dns":  mustParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8"),
+	"url":  mustParseUUID("6ba7b811-9dad-11d1-80b4-00c04fd430c8"),
+	"oid":  mustParseUUID("6ba7b812-9dad-11d1-80b4-00c04fd430c8"),
+	"x500": mustParseUUID("6ba7b814-9dad-11d1-80b4-00c04fd430c8"),
+}
+
+func parseUUID(s string) (uuid, error) {
+	var u uuid
+
+	t := s
+	if strings.HasPrefix(strings.ToLower(t), "urn:uuid:") {
+		t = t[len("urn:uuid:"):]
+	} else if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") {
+		t = t[1 : len(t)-1]
+	}
+
+	switch len(t) {
+	case 32:
+	case 36:
+		if t[8] != '-' || t[13] != '-' || t[18] != '-' || t[23] != '-' {
+			return u, errors.WithStack(&InvalidUUIDError{Input: s})
+		}
+
+		t = t[:8] + t[9:13] + t[14:18] + t[19:23] + t[24:]
+	default:
+		return u, errors.WithStack(&InvalidUUIDError{Input: s})
+	}
+
+	if _, err := hex.Decode(u[:], []byte(t)); err != nil {
+		return u, errors.WithStack(&InvalidUUIDError{Input: s})
+	}
+
+	return u, nil
+}
+
+func mustParseUUID(s string) uuid {
+	u, err := parseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return u
+}
+
+func (u uuid) String() string {
+	h := hex.EncodeToString(u[:])
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+func (u uuid) version() int {
+	return int(u[6] >> 4)
+}
+
+func (u uuid) variant() string {
+	switch {
+	case u[8]&0x80 == 0:
+		return "ncs"
+	case u[8]&0xc0 == 0x80:
+		return "rfc4122"
+	case u[8]&0xe0 == 0xc0:
+		return "microsoft"
+	default:
+		return "future"
+	}
+}
+
+// setVersion sets the version and the RFC 4122 variant bits.
+func (u *uuid) setVersion(version int) {
+	u[6] = u[6]&0x0f | byte(version)<<4
+	u[8] = u[8]&0x3f | 0x80
+}
+
+func newV4(ctx *context.Context) (uuid, error) {
+	var u uuid
+	if err := random(ctx, u[:]); err != nil {
+		return u, err
+	}
+
+	u.setVersion(4)
+	return u, nil
+}
+
+func newV5(namespace uuid, name []byte) uuid {
+	h := sha1.New()
+	h.Write(namespace[:])
+	h.Write(name)
+
+	var u uuid
+	copy(u[:], h.Sum(nil))
+
+	u.setVersion(5)
+	return u
+}
+
+func newV7(ctx *context.Context) (uuid, error) {
+	var u uuid
+	if err := random(ctx, u[6:]); err != nil {
+		return u, err
+	}
+
+	if err := putMillis(u[:], clock(ctx)); err != nil {
+		return u, err
+	}
+
+	u.setVersion(7)
+	return u, nil
+}
+
+func UUIDv4(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	u, err := newV4(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(types.Str(u.String()))}, nil
+}
+
+// UUIDv5 returns the name-based UUID for the input in the given namespace.
+// The namespace is either a UUID or one of the well-known names dns, url, oid
+// and x500.
+func UUIDv5(ctx *context.Context, in context.Valuer, namespaces []context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := types.StringOf(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []context.Valuer
+	for _, namespace := range namespaces {
+		nv, err := namespace.Value(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ns, err := types.StringOf(nv)
+		if err != nil {
+			return nil, err
+		}
+
+		nu, err := namespaceOf(ns)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, context.NewConstValuer(types.Str(newV5(nu, []byte(name)).String())))
+	}
+
+	return out, nil
+}
+
+func namespaceOf(s string) (uuid, error) {
+	if u, ok := namespaces[strings.ToLower(s)]; ok {
+		return u, nil
+	}
+
+	return parseUUID(s)
+}
+
+func UUIDv7(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	u, err := newV7(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{context.NewConstValuer(types.Str(u.String()))}, nil
+}
+
+func IsUUID(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return isValid(ctx, in, func(s string) error {
+		_, err := parseUUID(s)
+		return err
+	})
+}
+
+// ParseUUID returns an object with the canonical form of the input UUID, its
+// version and its variant.
+func ParseUUID(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return mapString(ctx, in, func(s string) (interface{}, error) {
+		u, err := parseUUID(s)
+		if err != nil {
+			return nil, err
+		}
+
+		o := types.NewObject()
+		o.Set("uuid", u.String())
+		o.Set("version", int64(u.version()))
+		o.Set("variant", u.variant())
+
+		return o, nil
+	})
+}
+
+// UUIDTime returns the time at which a version 7 UUID was generated, to the
+// millisecond.
+func UUIDTime(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	return mapString(ctx, in, func(s string) (interface{}, error) {
+		u, err := parseUUID(s)
+		if err != nil {
+			return nil, err
+		}
+
+		if u.version() != 7 {
+			return nil, errors.WithStack(&NoTimestampError{UUID: u.String(), Version: u.version()})
+		}
+
+		return types.Time{Time: millisOf(u[:])}, nil
+	})
+}
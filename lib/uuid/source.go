@@ -0,0 +1,63 @@
+package uuid
+
+import (
+	"crypto/rand"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	filqtime "github.com/reflect/filq/lib/time"
+)
+
+const (
+	// OptionRandom is the name of the context option that overrides the
+	// source of random bits for generated identifiers. Its value must be an
+	// io.Reader, like a seeded *math/rand.Rand.
+	OptionRandom = "uuid.random"
+)
+
+func random(ctx *context.Context, b []byte) error {
+	r, ok := ctx.Option(OptionRandom).(io.Reader)
+	if !ok {
+		r = rand.Reader
+	}
+
+	if _, err := io.ReadFull(r, b); err != nil {
+		return errors.Wrap(err, "reading random bits")
+	}
+
+	return nil
+}
+
+func clock(ctx *context.Context) time.Time {
+	if fn, ok := ctx.Option(filqtime.OptionClock).(func() time.Time); ok {
+		return fn()
+	}
+
+	return time.Now()
+}
+
+// putMillis writes a Unix time in milliseconds to the start of b as 48 bits,
+// big-endian. Times before 1970 or after the year 10889 do not fit.
+func putMillis(b []byte, t time.Time) error {
+	ms := t.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return errors.WithStack(&TimestampRangeError{Time: t})
+	}
+
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> uint(40-8*i))
+	}
+
+	return nil
+}
+
+func millisOf(b []byte) time.Time {
+	var ms int64
+	for i := 0; i < 6; i++ {
+		ms = ms<<8 | int64(b[i])
+	}
+
+	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond)).UTC()
+}
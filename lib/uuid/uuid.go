@@ -0,0 +1,48 @@
+// Package uuid generates, parses and validates UUIDs and ULIDs.
+//
+// UUIDs are formatted as lowercase hyphenated strings and ULIDs as uppercase
+// Crockford base32 strings. Parsing accepts the common alternative spellings:
+// uppercase UUIDs, UUIDs without hyphens, in braces or with a "urn:uuid:"
+// prefix, and lowercase ULIDs.
+//
+// Random bits are read from the io.Reader in the OptionRandom context option
+// and the current time is taken from the lib/time OptionClock option, so
+// generated identifiers can be made deterministic.
+package uuid
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(IsULID)
+	ctx.DefineFunction("isulid", fn)
+
+	fn, _ = function.NewFunction(IsUUID)
+	ctx.DefineFunction("isuuid", fn)
+
+	fn, _ = function.NewFunction(ParseULID)
+	ctx.DefineFunction("parseulid", fn)
+
+	fn, _ = function.NewFunction(ParseUUID)
+	ctx.DefineFunction("parseuuid", fn)
+
+	fn, _ = function.NewFunction(ULID)
+	ctx.DefineFunction("ulid", fn)
+
+	fn, _ = function.NewFunction(ULIDTime)
+	ctx.DefineFunction("ulidtime", fn)
+
+	fn, _ = function.NewFunction(UUIDTime)
+	ctx.DefineFunction("uuidtime", fn)
+
+	fn, _ = function.NewFunction(UUIDv4)
+	ctx.DefineFunction("uuidv4", fn)
+
+	fn, _ = function.NewFunction(UUIDv5)
+	ctx.DefineFunction("uuidv5", fn)
+
+	fn, _ = function.NewFunction(UUIDv7)
+	ctx.DefineFunction("uuidv7", fn)
+}
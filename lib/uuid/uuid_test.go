@@ -0,0 +1,152 @@
+package uuid
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	filqtime "github.com/reflect/filq/lib/time"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func newContext(seed int64, now time.Time) *context.Context {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+	ctx.DefineOption(OptionRandom, rand.New(rand.NewSource(seed)))
+	ctx.DefineOption(filqtime.OptionClock, func() time.Time { return now })
+
+	return ctx
+}
+
+func value(t *testing.T, ctx *context.Context, l []context.Valuer, err error) interface{} {
+	assert.NoError(t, err)
+	assert.Len(t, l, 1)
+
+	v, err := l[0].Value(ctx)
+	assert.NoError(t, err)
+
+	return v
+}
+
+func TestGenerateDeterministic(t *testing.T) {
+	now := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+
+	for _, fn := range []func(ctx *context.Context, in context.Valuer) ([]context.Valuer, error){UUIDv4, UUIDv7, ULID} {
+		ctx := newContext(42, now)
+		l, err := fn(ctx, context.NewConstValuer(nil))
+		a := value(t, ctx, l, err)
+
+		ctx = newContext(42, now)
+		l, err = fn(ctx, context.NewConstValuer(nil))
+		b := value(t, ctx, l, err)
+
+		assert.Equal(t, a, b)
+	}
+}
+
+func TestUUID(t *testing.T) {
+	now := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+	ctx := newContext(1, now)
+
+	l, err := UUIDv4(ctx, context.NewConstValuer(nil))
+	v4 := string(value(t, ctx, l, err).(types.Str))
+
+	u, err := parseUUID(v4)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, u.version())
+	assert.Equal(t, "rfc4122", u.variant())
+
+	l, err = UUIDv7(ctx, context.NewConstValuer(nil))
+	v7 := value(t, ctx, l, err)
+
+	l, err = UUIDTime(ctx, context.NewConstValuer(v7))
+	assert.Equal(t, types.Time{Time: now.Truncate(time.Millisecond)}, value(t, ctx, l, err))
+
+	_, err = UUIDTime(ctx, context.NewConstValuer(types.Str(v4)))
+	assert.Error(t, err)
+
+	l, err = UUIDv5(ctx, context.NewConstValuer(types.Str("www.example.com")), []context.Valuer{
+		context.NewConstValuer(types.Str("dns")),
+		context.NewConstValuer(types.Str("6ba7b810-9dad-11d1-80b4-00c04fd430c8")),
+	})
+	assert.NoError(t, err)
+	for _, v := range l {
+		s, err := v.Value(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, types.Str("2ed6657d-e927-568b-95e1-2665a8aea6a2"), s)
+	}
+
+	conds := []struct {
+		Input string
+		Valid bool
+	}{
+		{"2ED6657D-E927-568B-95E1-2665A8AEA6A2", true},
+		{"{2ed6657d-e927-568b-95e1-2665a8aea6a2}", true},
+		{"urn:uuid:2ed6657d-e927-568b-95e1-2665a8aea6a2", true},
+		{"2ed6657de927568b95e12665a8aea6a2", true},
+		{"2ed6657d-e927-568b-95e1-2665a8aea6a", false},
+		{"2ed6657d+e927-568b-95e1-2665a8aea6a2", false},
+		{"2ed6657d-e927-568b-95e1-2665a8aea6ag", false},
+	}
+
+	for _, cond := range conds {
+		u, err := parseUUID(cond.Input)
+		if !cond.Valid {
+			assert.Error(t, err, cond.Input)
+			continue
+		}
+
+		assert.NoError(t, err, cond.Input)
+		assert.Equal(t, "2ed6657d-e927-568b-95e1-2665a8aea6a2", u.String())
+	}
+}
+
+func TestULID(t *testing.T) {
+	u, err := parseULID("01arz3ndektsv4rrffq69g5fav")
+	assert.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", u.String())
+	assert.Equal(t, time.Date(2016, 7, 30, 23, 54, 10, 259000000, time.UTC), millisOf(u[:]))
+
+	for _, s := range []string{"01ARZ3NDEKTSV4RRFFQ69G5FA", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU"} {
+		_, err := parseULID(s)
+		assert.Error(t, err, s)
+	}
+
+	now := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+	ctx := newContext(1, now)
+
+	l, err := ULID(ctx, context.NewConstValuer(nil))
+	id := value(t, ctx, l, err)
+
+	l, err = ULIDTime(ctx, context.NewConstValuer(id))
+	assert.Equal(t, types.Time{Time: now.Truncate(time.Millisecond)}, value(t, ctx, l, err))
+}
+
+func TestTimestampRange(t *testing.T) {
+	for _, now := range []time.Time{
+		time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(10890, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		ctx := newContext(1, now)
+
+		_, err := UUIDv7(ctx, context.NewConstValuer(nil))
+		assert.IsType(t, &TimestampRangeError{}, errors.Cause(err), now.String())
+
+		_, err = ULID(ctx, context.NewConstValuer(nil))
+		assert.IsType(t, &TimestampRangeError{}, errors.Cause(err), now.String())
+	}
+
+	// Times after 2262 overflow an int64 of nanoseconds, but not of
+	// milliseconds.
+	now := time.Date(3000, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	ctx := newContext(1, now)
+
+	l, err := UUIDv7(ctx, context.NewConstValuer(nil))
+	u, err := parseUUID(string(value(t, ctx, l, err).(types.Str)))
+	assert.NoError(t, err)
+	assert.Equal(t, now, millisOf(u[:]))
+}
@@ -0,0 +1,21 @@
+package xml
+
+import (
+	"fmt"
+)
+
+type MismatchedElementError struct {
+	Wanted, Got string
+}
+
+func (e *MismatchedElementError) Error() string {
+	return fmt.Sprintf("element <%s> closed by </%s>", e.Wanted, e.Got)
+}
+
+type RootElementError struct {
+	Count int
+}
+
+func (e *RootElementError) Error() string {
+	return fmt.Sprintf("document must have exactly one root element (got %d)", e.Count)
+}
@@ -0,0 +1,191 @@
+package xml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func nameOf(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+
+	return n.Space + ":" + n.Local
+}
+
+// add stores a value under a key, collecting repeated keys into an array.
+func add(o *types.Object, key string, value interface{}) {
+	prev, ok := o.Get(key)
+	if !ok {
+		o.Set(key, value)
+		return
+	}
+
+	if a, ok := prev.(repeated); ok {
+		o.Set(key, append(a, value))
+	} else {
+		o.Set(key, repeated{prev, value})
+	}
+}
+
+// repeated marks arrays built from repeated elements, as opposed to values
+// that are arrays in their own right.
+type repeated []interface{}
+
+// decodeElement reads the content of an element up to and including its end
+// tag. Raw tokens are used so that namespace prefixes are kept as written.
+func decodeElement(d *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	o := types.NewObject()
+	for _, attr := range start.Attr {
+		o.Set(attrPrefix+nameOf(attr.Name), attr.Value)
+	}
+
+	var text strings.Builder
+	children := false
+
+	for {
+		t, err := d.RawToken()
+		if err == io.EOF {
+			return nil, errors.WithStack(io.ErrUnexpectedEOF)
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch tt := t.(type) {
+		case xml.StartElement:
+			child, err := decodeElement(d, tt)
+			if err != nil {
+				return nil, err
+			}
+
+			add(o, nameOf(tt.Name), child)
+			children = true
+		case xml.CharData:
+			text.Write(tt)
+		case xml.EndElement:
+			if nameOf(tt.Name) != nameOf(start.Name) {
+				return nil, errors.WithStack(&MismatchedElementError{Wanted: nameOf(start.Name), Got: nameOf(tt.Name)})
+			}
+
+			if !children && len(start.Attr) == 0 {
+				if text.Len() == 0 {
+					return nil, nil
+				}
+
+				return text.String(), nil
+			}
+
+			if s := strings.TrimSpace(text.String()); s != "" {
+				o.Set(textKey, s)
+			}
+
+			for _, key := range o.Keys() {
+				if a, ok := o.Get(key); ok {
+					if r, ok := a.(repeated); ok {
+						o.Set(key, []interface{}(r))
+					}
+				}
+			}
+
+			return o, nil
+		}
+	}
+}
+
+// decode reads a single document from the decoder.
+func decode(d *xml.Decoder) (interface{}, error) {
+	doc := types.NewObject()
+
+	for {
+		t, err := d.RawToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch tt := t.(type) {
+		case xml.StartElement:
+			if doc.Len() > 0 {
+				return nil, errors.WithStack(&RootElementError{Count: 2})
+			}
+
+			root, err := decodeElement(d, tt)
+			if err != nil {
+				return nil, err
+			}
+
+			doc.Set(nameOf(tt.Name), root)
+		case xml.CharData:
+			if len(bytes.TrimSpace(tt)) > 0 {
+				return nil, errors.New("text outside of root element")
+			}
+		case xml.EndElement:
+			return nil, errors.WithStack(&MismatchedElementError{Got: nameOf(tt.Name)})
+		}
+	}
+
+	if doc.Len() == 0 {
+		return nil, errors.WithStack(&RootElementError{})
+	}
+
+	return doc, nil
+}
+
+func from(v interface{}) (context.Valuer, error) {
+	d, ok := v.(*xml.Decoder)
+	if !ok {
+		if r, ok := v.(io.Reader); ok {
+			d = xml.NewDecoder(r)
+		} else {
+			switch vt := v.(type) {
+			case types.Str:
+				d = xml.NewDecoder(strings.NewReader(string(vt)))
+			case types.Bytes:
+				d = xml.NewDecoder(bytes.NewReader(vt))
+			default:
+				return nil, errors.WithStack(&context.UnexpectedTypeError{
+					Wanted: []reflect.Type{
+						reflect.TypeOf(&xml.Decoder{}),
+						reflect.TypeOf((*io.Reader)(nil)).Elem(),
+						reflect.TypeOf(types.Str("")),
+						reflect.TypeOf(types.Bytes([]byte{})),
+					},
+					Got: reflect.TypeOf(v),
+				})
+			}
+		}
+	}
+
+	lazy := func(ctx *context.Context) (interface{}, error) {
+		out, err := decode(d)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing XML")
+		}
+
+		return ctx.Convert(out), nil
+	}
+
+	return context.NewLazyValuer(lazy), nil
+}
+
+func FromXML(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vr, err := from(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{vr}, nil
+}
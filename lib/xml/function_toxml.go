@@ -0,0 +1,151 @@
+package xml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+)
+
+func encodeElement(ctx *context.Context, e *xml.Encoder, name string, v interface{}) error {
+	v = ctx.Convert(v)
+
+	// An array under a name is a repeated element.
+	if a, ok := v.(types.Array); ok {
+		for _, item := range a {
+			if err := encodeElement(ctx, e, name, item); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	o, ok := v.(*types.Object)
+	if !ok {
+		text, err := types.TextOf(v)
+		if err != nil {
+			return err
+		}
+
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+
+		if text != "" {
+			if err := e.EncodeToken(xml.CharData(text)); err != nil {
+				return err
+			}
+		}
+
+		return e.EncodeToken(start.End())
+	}
+
+	for _, key := range o.Keys() {
+		if !strings.HasPrefix(key, attrPrefix) {
+			continue
+		}
+
+		value, _ := o.Get(key)
+		text, err := types.TextOf(ctx.Convert(value))
+		if err != nil {
+			return err
+		}
+
+		start.Attr = append(start.Attr, xml.Attr{
+			Name:  xml.Name{Local: strings.TrimPrefix(key, attrPrefix)},
+			Value: text,
+		})
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, key := range o.Keys() {
+		if strings.HasPrefix(key, attrPrefix) {
+			continue
+		}
+
+		value, _ := o.Get(key)
+
+		if key == textKey {
+			text, err := types.TextOf(ctx.Convert(value))
+			if err != nil {
+				return err
+			}
+
+			if err := e.EncodeToken(xml.CharData(text)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := encodeElement(ctx, e, key, value); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+type toXML struct {
+	v *types.Object
+}
+
+func (tx *toXML) Value(ctx *context.Context) (interface{}, error) {
+	v := tx.v
+	if sortKeys, _ := ctx.Option(types.OptionSortKeys).(bool); sortKeys {
+		v = types.SortKeys(v).(*types.Object)
+	}
+
+	keys := v.Keys()
+	if len(keys) != 1 {
+		return nil, errors.WithStack(&RootElementError{Count: len(keys)})
+	}
+
+	// A repeated root element would not be a well-formed document.
+	root, _ := v.Get(keys[0])
+	if a, ok := ctx.Convert(root).(types.Array); ok {
+		return nil, errors.WithStack(&RootElementError{Count: len(a)})
+	}
+
+	var b bytes.Buffer
+	e := xml.NewEncoder(&b)
+
+	if err := encodeElement(ctx, e, keys[0], root); err != nil {
+		return nil, errors.Wrap(err, "marshaling XML")
+	}
+
+	if err := e.Flush(); err != nil {
+		return nil, errors.Wrap(err, "marshaling XML")
+	}
+
+	return types.Bytes(b.Bytes()), nil
+}
+
+func ToXML(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	o, ok := v.(*types.Object)
+	if !ok {
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(&types.Object{}),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	return []context.Valuer{&toXML{o}}, nil
+}
@@ -0,0 +1,40 @@
+// Package xml converts between XML documents and filq values.
+//
+// A document is represented as an object with a single key, the name of its
+// root element. Each element is converted as follows:
+//
+//   - An element with neither attributes nor child elements is its text, or
+//     null if it is empty.
+//   - Otherwise, it is an object. Attributes are stored under their name
+//     prefixed with "@", child elements under their name, and any text that is
+//     not only whitespace under "#text".
+//   - Child elements that share a name are collected into an array in document
+//     order. The relative order of differently named children is not kept.
+//
+// Names are used exactly as written, including any namespace prefix (for
+// example "soap:Body"), and namespace declarations are kept as attributes (for
+// example "@xmlns:soap"). Comments, processing instructions and directives are
+// discarded.
+package xml
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+const (
+	attrPrefix = "@"
+	textKey    = "#text"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(FromXML)
+	ctx.DefineFunction("fromxml", fn)
+
+	fn, _ = function.NewFunction(ToXML)
+	ctx.DefineFunction("toxml", fn)
+}
+
+func NewValuer(in interface{}) (context.Valuer, error) {
+	return from(in)
+}
@@ -0,0 +1,64 @@
+package xml
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const document = `<?xml version="1.0"?>
+<s:Envelope xmlns:s="urn:s">
+  <!-- ignored -->
+  <s:Body>
+    <item id="1">a &amp; <![CDATA[<b>]]></item>
+    <item id="2"/>
+    <empty/>
+    <name>x</name>
+  </s:Body>
+</s:Envelope>`
+
+func TestFromXML(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	vr, err := NewValuer(types.Str(document))
+	assert.NoError(t, err)
+
+	v, err := vr.Value(ctx)
+	assert.NoError(t, err)
+
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"s:Envelope": {
+			"@xmlns:s": "urn:s",
+			"s:Body": {
+				"item": [{"@id": "1", "#text": "a & <b>"}, {"@id": "2"}],
+				"empty": null,
+				"name": "x"
+			}
+		}
+	}`, string(b))
+
+	vrs, err := ToXML(ctx, context.NewConstValuer(v))
+	assert.NoError(t, err)
+
+	out, err := vrs[0].Value(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, `<s:Envelope xmlns:s="urn:s"><s:Body><item id="1">a &amp; &lt;b&gt;</item><item id="2"></item><empty></empty><name>x</name></s:Body></s:Envelope>`, string(out.(types.Bytes)))
+}
+
+func TestFromXMLInvalid(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+
+	for _, invalid := range []string{"", "<a></b>", "<a/><b/>", "<a>", "text"} {
+		vr, err := NewValuer(types.Str(invalid))
+		assert.NoError(t, err)
+
+		_, err = vr.Value(ctx)
+		assert.Error(t, err, invalid)
+	}
+}
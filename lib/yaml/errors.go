@@ -0,0 +1,31 @@
+package yaml
+
+import (
+	"fmt"
+)
+
+type RecursiveAliasError struct {
+	Anchor string
+	Line   int
+}
+
+func (e *RecursiveAliasError) Error() string {
+	return fmt.Sprintf("alias *%s on line %d refers to itself", e.Anchor, e.Line)
+}
+
+type InvalidMergeError struct {
+	Line int
+}
+
+func (e *InvalidMergeError) Error() string {
+	return fmt.Sprintf("merge key on line %d must refer to a mapping or a sequence of mappings", e.Line)
+}
+
+type AliasExpansionError struct {
+	Limit int
+	Line  int
+}
+
+func (e *AliasExpansionError) Error() string {
+	return fmt.Sprintf("expanding the alias on line %d produces more than %d values", e.Line, e.Limit)
+}
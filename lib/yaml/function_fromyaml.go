@@ -0,0 +1,277 @@
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"gopkg.in/yaml.v3"
+)
+
+const mergeTag = "!!merge"
+
+// maxAliasValues is the number of values that expanding aliases may produce
+// in one document. Each alias is expanded into a copy of its anchor, so
+// without a limit a small document with nested aliases can expand
+// exponentially.
+const maxAliasValues = 1000000
+
+// converter turns a node tree into plain values, keeping track of the aliases
+// being expanded so that recursive anchors are rejected, and of the number of
+// values produced by them.
+type converter struct {
+	expanding   map[*yaml.Node]bool
+	aliasDepth  int
+	aliasValues int
+}
+
+func (c *converter) scalar(n *yaml.Node) (interface{}, error) {
+	switch n.ShortTag() {
+	case "!!str", "!!timestamp":
+		return n.Value, nil
+	case "!!binary":
+		var b []byte
+		if err := n.Decode(&b); err != nil {
+			return nil, err
+		}
+
+		return b, nil
+	}
+
+	var v interface{}
+	if err := n.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	switch vt := v.(type) {
+	case int:
+		return int64(vt), nil
+	case uint64:
+		return json.Number(strconv.FormatUint(vt, 10)), nil
+	default:
+		return vt, nil
+	}
+}
+
+// merge copies the keys of the mappings referred to by a merge key into an
+// object, without replacing keys that are already set.
+func (c *converter) merge(o *types.Object, n *yaml.Node) error {
+	target := n
+	if target.Kind == yaml.AliasNode {
+		target = target.Alias
+	}
+
+	var sources []*yaml.Node
+	switch target.Kind {
+	case yaml.MappingNode:
+		sources = []*yaml.Node{n}
+	case yaml.SequenceNode:
+		// Earlier mappings in the sequence take precedence.
+		sources = target.Content
+	default:
+		return errors.WithStack(&InvalidMergeError{Line: n.Line})
+	}
+
+	for _, source := range sources {
+		v, err := c.convert(source)
+		if err != nil {
+			return err
+		}
+
+		so, ok := v.(*types.Object)
+		if !ok {
+			return errors.WithStack(&InvalidMergeError{Line: source.Line})
+		}
+
+		for _, key := range so.Keys() {
+			if _, ok := o.Get(key); !ok {
+				value, _ := so.Get(key)
+				o.Set(key, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *converter) mapping(n *yaml.Node) (interface{}, error) {
+	o := types.NewObject()
+
+	// Merges are applied after the explicit keys so that those take
+	// precedence regardless of where the merge key appears.
+	var merges []*yaml.Node
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		kn, vn := n.Content[i], n.Content[i+1]
+		if kn.Kind == yaml.ScalarNode && kn.ShortTag() == mergeTag {
+			merges = append(merges, vn)
+			continue
+		}
+
+		kv, err := c.convert(kn)
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := kv.(string)
+		if !ok {
+			// Keys that are not strings are stored as their JSON
+			// representation.
+			b, err := json.Marshal(types.SortKeys(kv))
+			if err != nil {
+				return nil, err
+			}
+
+			key = string(b)
+		}
+
+		value, err := c.convert(vn)
+		if err != nil {
+			return nil, err
+		}
+
+		o.Set(key, value)
+	}
+
+	for _, merge := range merges {
+		if err := c.merge(o, merge); err != nil {
+			return nil, err
+		}
+	}
+
+	return o, nil
+}
+
+func (c *converter) convert(n *yaml.Node) (interface{}, error) {
+	if c.aliasDepth > 0 {
+		c.aliasValues++
+		if c.aliasValues > maxAliasValues {
+			return nil, errors.WithStack(&AliasExpansionError{Limit: maxAliasValues, Line: n.Line})
+		}
+	}
+
+	switch n.Kind {
+	case yaml.DocumentNode:
+		return c.convert(n.Content[0])
+	case yaml.SequenceNode:
+		a := make([]interface{}, len(n.Content))
+		for i, item := range n.Content {
+			v, err := c.convert(item)
+			if err != nil {
+				return nil, err
+			}
+
+			a[i] = v
+		}
+
+		return a, nil
+	case yaml.MappingNode:
+		return c.mapping(n)
+	case yaml.AliasNode:
+		if c.expanding[n.Alias] {
+			return nil, errors.WithStack(&RecursiveAliasError{Anchor: n.Value, Line: n.Line})
+		}
+
+		c.expanding[n.Alias] = true
+		c.aliasDepth++
+		defer func() {
+			delete(c.expanding, n.Alias)
+			c.aliasDepth--
+		}()
+
+		return c.convert(n.Alias)
+	default:
+		return c.scalar(n)
+	}
+}
+
+func isEmpty(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null" && n.Value == "" && n.Anchor == ""
+}
+
+// decode reads every non-empty document from the decoder.
+func decode(d *yaml.Decoder) ([]interface{}, error) {
+	var docs []interface{}
+
+	for {
+		var n yaml.Node
+		if err := d.Decode(&n); err == io.EOF {
+			return docs, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		// Documents without any content, like the one after a trailing
+		// separator, produce no output. The parser represents their
+		// content as an empty null scalar.
+		if len(n.Content) == 0 || isEmpty(n.Content[0]) {
+			continue
+		}
+
+		c := &converter{expanding: make(map[*yaml.Node]bool)}
+
+		doc, err := c.convert(&n)
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, doc)
+	}
+}
+
+func from(v interface{}) (*yaml.Decoder, error) {
+	if d, ok := v.(*yaml.Decoder); ok {
+		return d, nil
+	} else if r, ok := v.(io.Reader); ok {
+		return yaml.NewDecoder(r), nil
+	}
+
+	switch vt := v.(type) {
+	case types.Str:
+		return yaml.NewDecoder(strings.NewReader(string(vt))), nil
+	case types.Bytes:
+		return yaml.NewDecoder(bytes.NewReader(vt)), nil
+	default:
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(&yaml.Decoder{}),
+				reflect.TypeOf((*io.Reader)(nil)).Elem(),
+				reflect.TypeOf(types.Str("")),
+				reflect.TypeOf(types.Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+}
+
+// FromYAML decodes a YAML stream. Unlike fromjson, the stream is decoded
+// eagerly, because the number of outputs is the number of documents in it.
+func FromYAML(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := from(v)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := decode(d)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing YAML")
+	}
+
+	out := make([]context.Valuer, len(docs))
+	for i, doc := range docs {
+		out[i] = context.NewConstValuer(doc)
+	}
+
+	return out, nil
+}
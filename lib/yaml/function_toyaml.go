@@ -0,0 +1,153 @@
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"gopkg.in/yaml.v3"
+)
+
+// yaml11Bools are the strings that YAML 1.1 parsers, which are still common,
+// read as booleans.
+var yaml11Bools = map[string]bool{
+	"y": true, "Y": true, "yes": true, "Yes": true, "YES": true,
+	"n": true, "N": true, "no": true, "No": true, "NO": true,
+	"on": true, "On": true, "ON": true,
+	"off": true, "Off": true, "OFF": true,
+}
+
+func scalarNode(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+func strNode(s string) *yaml.Node {
+	n := scalarNode("!!str", s)
+	if yaml11Bools[s] {
+		n.Style = yaml.DoubleQuotedStyle
+	}
+
+	return n
+}
+
+func floatNode(f float64) *yaml.Node {
+	switch {
+	case math.IsNaN(f):
+		return scalarNode("!!float", ".nan")
+	case math.IsInf(f, 1):
+		return scalarNode("!!float", ".inf")
+	case math.IsInf(f, -1):
+		return scalarNode("!!float", "-.inf")
+	default:
+		return scalarNode("!!float", strconv.FormatFloat(f, 'g', -1, 64))
+	}
+}
+
+// encode builds a node tree for a value. Mappings keep the order of the keys
+// of objects.
+func encode(ctx *context.Context, v interface{}) (*yaml.Node, error) {
+	switch vt := ctx.Convert(v).(type) {
+	case nil:
+		return scalarNode("!!null", "null"), nil
+	case bool:
+		return scalarNode("!!bool", strconv.FormatBool(vt)), nil
+	case types.Int:
+		return scalarNode("!!int", strconv.FormatInt(int64(vt), 10)), nil
+	case types.Float:
+		return floatNode(float64(vt)), nil
+	case types.Number:
+		if strings.ContainsAny(string(vt), ".eE") {
+			return scalarNode("!!float", string(vt)), nil
+		}
+
+		return scalarNode("!!int", string(vt)), nil
+	case types.Str:
+		return strNode(string(vt)), nil
+	case types.Bytes:
+		return strNode(string(vt)), nil
+	case types.Array:
+		n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, item := range vt {
+			in, err := encode(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+
+			n.Content = append(n.Content, in)
+		}
+
+		return n, nil
+	case *types.Object:
+		n := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, key := range vt.Keys() {
+			value, _ := vt.Get(key)
+
+			vn, err := encode(ctx, value)
+			if err != nil {
+				return nil, err
+			}
+
+			n.Content = append(n.Content, strNode(key), vn)
+		}
+
+		return n, nil
+	default:
+		// Anything else, like times, is encoded the same way as in JSON.
+		b, err := json.Marshal(vt)
+		if err != nil {
+			return nil, err
+		}
+
+		var jv interface{}
+		if err := json.Unmarshal(b, &jv); err != nil {
+			return nil, err
+		}
+
+		return encode(ctx, jv)
+	}
+}
+
+type toYAML struct {
+	v interface{}
+}
+
+func (ty *toYAML) Value(ctx *context.Context) (interface{}, error) {
+	v := ty.v
+	if sortKeys, _ := ctx.Option(types.OptionSortKeys).(bool); sortKeys {
+		v = types.SortKeys(v)
+	}
+
+	n, err := encode(ctx, v)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling YAML")
+	}
+
+	var b bytes.Buffer
+
+	e := yaml.NewEncoder(&b)
+	e.SetIndent(2)
+
+	if err := e.Encode(n); err != nil {
+		return nil, errors.Wrap(err, "marshaling YAML")
+	}
+
+	if err := e.Close(); err != nil {
+		return nil, errors.Wrap(err, "marshaling YAML")
+	}
+
+	return types.Bytes(b.Bytes()), nil
+}
+
+func ToYAML(ctx *context.Context, in context.Valuer) ([]context.Valuer, error) {
+	v, err := in.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return []context.Valuer{&toYAML{v}}, nil
+}
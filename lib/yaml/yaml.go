@@ -0,0 +1,20 @@
+// Package yaml converts between YAML streams and filq values.
+//
+// Decoding follows the YAML 1.2 core schema: timestamps are not recognized and
+// are decoded as strings. Anchors, aliases and merge keys ("<<") are resolved,
+// and the keys of mappings keep their order. Each document in a stream is a
+// separate output.
+package yaml
+
+import (
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/function"
+)
+
+func DefineIn(ctx *context.Context) {
+	fn, _ := function.NewFunction(FromYAML)
+	ctx.DefineFunction("fromyaml", fn)
+
+	fn, _ = function.NewFunction(ToYAML)
+	ctx.DefineFunction("toyaml", fn)
+}
@@ -0,0 +1,93 @@
+package yaml
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+	"github.com/reflect/filq/types"
+	"github.com/stretchr/testify/assert"
+)
+
+const stream = `base: &base
+  image: nginx
+  port: 80
+svc:
+  <<: *base
+  port: 8080
+  tags: [a, "1", 2, yes, ~, 1.5, 2001-12-14]
+---
+- second
+---
+`
+
+func TestFromYAML(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	vrs, err := FromYAML(ctx, context.NewConstValuer(stream))
+	assert.NoError(t, err)
+	assert.Len(t, vrs, 2)
+
+	var docs []string
+	for _, vr := range vrs {
+		v, err := vr.Value(ctx)
+		assert.NoError(t, err)
+
+		b, err := json.Marshal(v)
+		assert.NoError(t, err)
+
+		docs = append(docs, string(b))
+	}
+
+	assert.Equal(t, []string{
+		`{"base":{"image":"nginx","port":80},"svc":{"port":8080,"tags":["a","1",2,"yes",null,1.5,"2001-12-14"],"image":"nginx"}}`,
+		`["second"]`,
+	}, docs)
+
+	_, err = FromYAML(ctx, context.NewConstValuer("a: &a [*a]"))
+	assert.IsType(t, &RecursiveAliasError{}, errors.Cause(err))
+}
+
+func TestFromYAMLAliasBomb(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	// Each level has ten aliases to the previous one, so the last expands to
+	// 10^9 values.
+	bomb := `a: &a ["lol"]
+b: &b [*a, *a, *a, *a, *a, *a, *a, *a, *a, *a]
+c: &c [*b, *b, *b, *b, *b, *b, *b, *b, *b, *b]
+d: &d [*c, *c, *c, *c, *c, *c, *c, *c, *c, *c]
+e: &e [*d, *d, *d, *d, *d, *d, *d, *d, *d, *d]
+f: &f [*e, *e, *e, *e, *e, *e, *e, *e, *e, *e]
+g: &g [*f, *f, *f, *f, *f, *f, *f, *f, *f, *f]
+h: &h [*g, *g, *g, *g, *g, *g, *g, *g, *g, *g]
+i: &i [*h, *h, *h, *h, *h, *h, *h, *h, *h, *h]
+`
+
+	_, err := FromYAML(ctx, context.NewConstValuer(bomb))
+	assert.IsType(t, &AliasExpansionError{}, errors.Cause(err))
+
+	// Ordinary use of aliases is unaffected.
+	_, err = FromYAML(ctx, context.NewConstValuer(bomb[:strings.Index(bomb, "f:")]))
+	assert.NoError(t, err)
+}
+
+func TestToYAML(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	types.DefineIn(ctx)
+
+	o := types.NewObject()
+	o.Set("z", []interface{}{int64(1), "on", nil})
+	o.Set("a", "true")
+
+	vrs, err := ToYAML(ctx, context.NewConstValuer(o))
+	assert.NoError(t, err)
+
+	v, err := vrs[0].Value(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "z:\n  - 1\n  - \"on\"\n  - null\na: \"true\"\n", string(v.(types.Bytes)))
+}
@@ -28,7 +28,7 @@ func TestStringParser(t *testing.T) {
 		Filters: []filter.Filter{
 			constFilter("test "),
 			&filter.Scope{
-				&filter.Pipe{
+				Filter: &filter.Pipe{
 					Filter: &filter.Selector{
 						Recall: &context.PipeRecall{},
 						Tree:   []filter.Filter{constFilter("interpolation")},
@@ -345,5 +345,5 @@ func stringFilter(in string) *filter.String {
 }
 
 func constFilter(in interface{}) *filter.Const {
-	return &filter.Const{context.NewConstValuer(in)}
+	return &filter.Const{Valuer: context.NewConstValuer(in)}
 }
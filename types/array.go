@@ -25,41 +25,51 @@ func (a Array) Format(f fmt.State, c rune) {
 }
 
 func (a Array) selectIndex(ctx *context.Context, idx int, tree []context.Valuer) (context.Valuer, error) {
-	if idx < 0 || idx >= len(a) {
-		return context.NewConstValuer(nil), nil
+	if idx < 0 && ctx.Dialect() == context.DialectJQ {
+		idx += len(a)
 	}
 
-	sel := ctx.Convert(a[idx])
-
-	if len(tree) == 1 {
-		return context.NewConstValuer(sel), nil
-	} else if selectable, ok := sel.(context.Sel); ok {
-		return selectable.Select(ctx, tree[1:])
+	if idx < 0 || idx >= len(a) {
+		return context.NewConstValuer(nil), nil
 	}
 
-	return nil, &context.UnexpectedTypeError{
-		Wanted: []reflect.Type{
-			reflect.TypeOf((*context.Sel)(nil)).Elem(),
-		},
-		Got: reflect.TypeOf(sel),
-	}
+	return selectNext(ctx, ctx.Convert(a[idx]), tree[1:])
 }
 
 func (a Array) selectSlice(ctx *context.Context, slice Slice, tree []context.Valuer) (context.Valuer, error) {
 	var out []interface{}
 
-	min := int(slice.Left)
-	if min < 0 {
-		min = 0
-	}
+	if ctx.Dialect() == context.DialectJQ {
+		// The upper bound is exclusive and negative bounds are relative to
+		// the end of the array.
+		min, max := int(slice.Left), int(slice.Right)
+		if min < 0 {
+			min += len(a)
+		}
 
-	max := int(slice.Right)
-	if max >= len(a) {
-		max = len(a) - 1
-	}
+		if max < 0 {
+			max += len(a)
+		}
+
+		for i := min; i < max; i++ {
+			if i >= 0 && i < len(a) {
+				out = append(out, a[i])
+			}
+		}
+	} else {
+		min := int(slice.Left)
+		if min < 0 {
+			min = 0
+		}
 
-	for i := min; i <= max; i++ {
-		out = append(out, a[i])
+		max := int(slice.Right)
+		if max >= len(a) {
+			max = len(a) - 1
+		}
+
+		for i := min; i <= max; i++ {
+			out = append(out, a[i])
+		}
 	}
 
 	if len(tree) == 1 {
@@ -85,16 +95,12 @@ func (a Array) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
 	}
 
 	for i, test := range a {
-		if ve, ok := test.(context.Eq); ok {
-			eq, err := ve.Equal(ctx, context.NewConstValuer(oa[i]))
-			if err != nil {
-				return false, err
-			}
+		eq, err := Equal(ctx, test, oa[i])
+		if err != nil {
+			return false, err
+		}
 
-			if !eq {
-				return false, nil
-			}
-		} else if test != oa[i] {
+		if !eq {
 			return false, nil
 		}
 	}
@@ -102,6 +108,34 @@ func (a Array) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
 	return true, nil
 }
 
+func (a Array) Compare(ctx *context.Context, other context.Valuer) (int, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	oa, ok := ov.(Array)
+	if !ok {
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{reflect.TypeOf(Array{})},
+			Got:    reflect.TypeOf(ov),
+		})
+	}
+
+	for i := 0; i < len(a) && i < len(oa); i++ {
+		c, err := Compare(ctx, a[i], oa[i])
+		if err != nil {
+			return 0, err
+		}
+
+		if c != 0 {
+			return c, nil
+		}
+	}
+
+	return compareInts(Int(len(a)), Int(len(oa))), nil
+}
+
 func (a Array) Index(ctx *context.Context, key context.Valuer) (context.Valuer, error) {
 	v, err := key.Value(ctx)
 	if err != nil {
@@ -109,16 +143,12 @@ func (a Array) Index(ctx *context.Context, key context.Valuer) (context.Valuer,
 	}
 
 	for i, candidate := range a {
-		if ve, ok := v.(context.Eq); ok {
-			eq, err := ve.Equal(ctx, context.NewConstValuer(candidate))
-			if err != nil {
-				return nil, err
-			}
+		eq, err := Equal(ctx, v, candidate)
+		if err != nil {
+			return nil, err
+		}
 
-			if eq {
-				return context.NewConstValuer(i), nil
-			}
-		} else if v == ctx.Convert(candidate) {
+		if eq {
 			return context.NewConstValuer(i), nil
 		}
 	}
@@ -29,6 +29,28 @@ func (b Bytes) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
 	return false, nil
 }
 
+func (b Bytes) Compare(ctx *context.Context, other context.Valuer) (int, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	switch ot := ov.(type) {
+	case Str:
+		return bytes.Compare(b, []byte(ot)), nil
+	case Bytes:
+		return bytes.Compare(b, ot), nil
+	default:
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(Str("")),
+				reflect.TypeOf(Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(ov),
+		})
+	}
+}
+
 func (b Bytes) Format(f fmt.State, c rune) {
 	if c != 'v' || !f.Flag('+') {
 		FormatDefault(f, c, []byte(b))
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+)
+
+// The ranks of each type in the total order of values. The order of the types
+// shared with jq is the same as in jq.
+const (
+	rankNull = iota
+	rankFalse
+	rankTrue
+	rankNumber
+	rankString
+	rankArray
+	rankObject
+	rankTime
+	rankDuration
+	rankIP
+	rankSemver
+	rankEntry
+	rankOther
+)
+
+func rank(v interface{}) int {
+	switch vt := v.(type) {
+	case nil:
+		return rankNull
+	case bool:
+		if vt {
+			return rankTrue
+		}
+
+		return rankFalse
+	case Int, Float, Number:
+		return rankNumber
+	case Str, Bytes:
+		return rankString
+	case Array:
+		return rankArray
+	case *Object:
+		return rankObject
+	case Time:
+		return rankTime
+	case Duration:
+		return rankDuration
+	case IP:
+		return rankIP
+	case Semver:
+		return rankSemver
+	case Entry:
+		return rankEntry
+	default:
+		return rankOther
+	}
+}
+
+// Compare orders any two values. Values of different types are ordered by
+// type: null < false < true < numbers < strings < arrays < objects < times <
+// durations < IP addresses < versions < entries. Values of the same type are
+// ordered using their context.Cmp implementation.
+func Compare(ctx *context.Context, a, b interface{}) (int, error) {
+	a, b = ctx.Convert(a), ctx.Convert(b)
+
+	ra, rb := rank(a), rank(b)
+	if ra != rb {
+		return compareInts(Int(ra), Int(rb)), nil
+	}
+
+	switch ra {
+	case rankNull, rankFalse, rankTrue:
+		return 0, nil
+	}
+
+	ac, ok := a.(context.Cmp)
+	if !ok {
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf((*context.Cmp)(nil)).Elem(),
+			},
+			Got: reflect.TypeOf(a),
+		})
+	}
+
+	return ac.Compare(ctx, context.NewConstValuer(b))
+}
+
+// Equal determines whether two values are structurally equal. Values that do
+// not implement context.Eq are compared directly if possible and deeply
+// otherwise.
+func Equal(ctx *context.Context, a, b interface{}) (bool, error) {
+	a, b = ctx.Convert(a), ctx.Convert(b)
+
+	if ae, ok := a.(context.Eq); ok {
+		return ae.Equal(ctx, context.NewConstValuer(b))
+	} else if be, ok := b.(context.Eq); ok {
+		return be.Equal(ctx, context.NewConstValuer(a))
+	}
+
+	if a == nil || b == nil {
+		return a == nil && b == nil, nil
+	}
+
+	if !reflect.TypeOf(a).Comparable() || !reflect.TypeOf(b).Comparable() {
+		return reflect.DeepEqual(a, b), nil
+	}
+
+	return a == b, nil
+}
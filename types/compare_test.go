@@ -0,0 +1,158 @@
+package types
+
+import (
+	"math"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/reflect/filq/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	DefineIn(ctx)
+
+	// Each value is strictly less than the next.
+	ordered := []interface{}{
+		nil,
+		false,
+		true,
+		int64(-1),
+		0.5,
+		Number("1"),
+		"",
+		[]byte("a"),
+		"ab",
+		[]interface{}{},
+		[]interface{}{int64(1)},
+		[]interface{}{int64(1), nil},
+		[]interface{}{int64(2)},
+		map[string]interface{}{},
+		map[string]interface{}{"a": int64(2)},
+		map[string]interface{}{"a": int64(1), "b": int64(1)},
+		map[string]interface{}{"b": int64(0)},
+		time.Unix(0, 0),
+		time.Unix(1, 0),
+		-time.Minute,
+		time.Second,
+		netip.MustParseAddr("10.0.0.2"),
+		netip.MustParseAddr("10.0.0.10"),
+		netip.MustParseAddr("::1"),
+		Semver{Major: 1, Prerelease: []string{"alpha"}},
+		Semver{Major: 1, Prerelease: []string{"alpha", "1"}},
+		Semver{Major: 1, Prerelease: []string{"alpha", "beta"}},
+		Semver{Major: 1, Prerelease: []string{"beta", "2"}},
+		Semver{Major: 1, Prerelease: []string{"beta", "11"}},
+		Semver{Major: 1, Prerelease: []string{"rc", "1"}},
+		Semver{Major: 1},
+		Semver{Major: 1, Minor: 10},
+		Semver{Major: 2},
+		Entry{Key: "a", Value: int64(1)},
+	}
+
+	for i, a := range ordered {
+		for j, b := range ordered {
+			c, err := Compare(ctx, a, b)
+			assert.NoError(t, err)
+
+			switch {
+			case i < j:
+				assert.Equal(t, -1, c, "%v < %v", a, b)
+			case i > j:
+				assert.Equal(t, 1, c, "%v > %v", a, b)
+			default:
+				assert.Equal(t, 0, c, "%v = %v", a, b)
+			}
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	DefineIn(ctx)
+
+	conds := []struct {
+		A, B     interface{}
+		Expected bool
+	}{
+		{nil, nil, true},
+		{nil, false, false},
+		{true, true, true},
+		{int64(1), 1.0, true},
+		{"a", []byte("a"), true},
+		{map[string]interface{}{"a": nil}, nil, false},
+		{nil, map[string]interface{}{}, false},
+		{map[string]interface{}{"a": int64(1)}, []interface{}{int64(1)}, false},
+		{
+			[]interface{}{map[string]int{"a": 1}},
+			[]interface{}{map[string]int{"a": 1}},
+			true,
+		},
+		{
+			map[string]interface{}{"a": []interface{}{true, "b"}},
+			map[string]interface{}{"a": []interface{}{true, []byte("b")}},
+			true,
+		},
+		{time.Unix(0, 0), time.Unix(0, 0).In(time.FixedZone("X", 3600)), true},
+		{netip.MustParseAddr("::ffff:10.0.0.1"), netip.MustParseAddr("10.0.0.1"), true},
+		{netip.MustParseAddr("::ffff:10.0.0.1"), netip.MustParseAddr("::a00:1"), false},
+	}
+
+	for _, cond := range conds {
+		eq, err := Equal(ctx, cond.A, cond.B)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, eq, "%v == %v", cond.A, cond.B)
+	}
+}
+
+func TestCompareMappedIP(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	DefineIn(ctx)
+
+	mapped := netip.MustParseAddr("::ffff:10.0.0.3")
+
+	conds := []struct {
+		Other    netip.Addr
+		Expected int
+	}{
+		{netip.MustParseAddr("10.0.0.2"), 1},
+		{netip.MustParseAddr("10.0.0.3"), 0},
+		{netip.MustParseAddr("10.0.0.10"), -1},
+		{netip.MustParseAddr("::1"), -1},
+	}
+
+	for _, cond := range conds {
+		c, err := Compare(ctx, mapped, cond.Other)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, c, "%v <=> %v", mapped, cond.Other)
+	}
+}
+
+func TestCompareInexactNumbers(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	DefineIn(ctx)
+
+	// Numbers whose exponent is too large for an exact value.
+	conds := []struct {
+		A, B     interface{}
+		Expected int
+	}{
+		{Number("1e99999999"), Int(1), 1},
+		{Number("-1e99999999"), Int(1), -1},
+		{Int(1), Number("1e99999999"), -1},
+		{Number("1e99999999"), Number("1e400"), 1},
+		{Number("1e99999999"), Float(math.Inf(1)), 0},
+		{Number("1e-99999999"), Int(1), -1},
+		{Number("1e-99999999"), Int(0), 1},
+		{Number("-1e-99999999"), Int(0), -1},
+		{Number("1e99999999"), Float(math.NaN()), 1},
+	}
+
+	for _, cond := range conds {
+		c, err := Compare(ctx, cond.A, cond.B)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, c, "%v <=> %v", cond.A, cond.B)
+	}
+}
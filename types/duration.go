@@ -0,0 +1,104 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+)
+
+type Duration struct {
+	time.Duration
+}
+
+type durationSelector struct {
+	d Duration
+	v context.Valuer
+}
+
+func (s *durationSelector) Value(ctx *context.Context) (interface{}, error) {
+	v, err := s.v.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sub string
+	if b, ok := v.(Bytes); ok {
+		sub = string(b)
+	} else if s, ok := v.(Str); ok {
+		sub = string(s)
+	} else {
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(Str("")),
+				reflect.TypeOf(Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	switch sub {
+	case "hours":
+		return Float(s.d.Hours()), nil
+	case "minutes":
+		return Float(s.d.Minutes()), nil
+	case "seconds":
+		return Float(s.d.Seconds()), nil
+	case "milliseconds":
+		return Int(s.d.Nanoseconds() / int64(time.Millisecond)), nil
+	case "nanoseconds":
+		return Int(s.d.Nanoseconds()), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (d Duration) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if od, ok := ov.(Duration); ok {
+		return d.Duration == od.Duration, nil
+	}
+
+	return false, nil
+}
+
+func (d Duration) Compare(ctx *context.Context, other context.Valuer) (int, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	od, ok := ov.(Duration)
+	if !ok {
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{reflect.TypeOf(Duration{})},
+			Got:    reflect.TypeOf(ov),
+		})
+	}
+
+	return compareInts(Int(d.Duration), Int(od.Duration)), nil
+}
+
+func (d Duration) Select(ctx *context.Context, tree []context.Valuer) (context.Valuer, error) {
+	if len(tree) != 1 {
+		return context.NewConstValuer(nil), nil
+	}
+
+	return &durationSelector{d: d, v: tree[0]}, nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+type DurationConverter struct{}
+
+func (dco *DurationConverter) Convert(in interface{}) interface{} {
+	return Duration{in.(time.Duration)}
+}
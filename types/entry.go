@@ -52,6 +52,47 @@ func (e Entry) Format(f fmt.State, c rune) {
 	fmt.Fprintf(f, "%+v = %+v", e.Key, e.Value)
 }
 
+func (e Entry) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	oe, ok := ov.(Entry)
+	if !ok {
+		return false, nil
+	}
+
+	eq, err := Equal(ctx, e.Key, oe.Key)
+	if err != nil || !eq {
+		return false, err
+	}
+
+	return Equal(ctx, e.Value, oe.Value)
+}
+
+func (e Entry) Compare(ctx *context.Context, other context.Valuer) (int, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	oe, ok := ov.(Entry)
+	if !ok {
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{reflect.TypeOf(Entry{})},
+			Got:    reflect.TypeOf(ov),
+		})
+	}
+
+	c, err := Compare(ctx, e.Key, oe.Key)
+	if err != nil || c != 0 {
+		return c, err
+	}
+
+	return Compare(ctx, e.Value, oe.Value)
+}
+
 func (e Entry) Select(ctx *context.Context, tree []context.Valuer) (context.Valuer, error) {
 	if len(tree) != 1 {
 		return context.NewConstValuer(nil), nil
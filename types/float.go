@@ -15,8 +15,8 @@ func (f Float) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
 		return false, err
 	}
 
-	if of, ok := ov.(Float); ok {
-		return float64(f) == float64(of), nil
+	if c, ok := CompareNumbers(f, ov); ok {
+		return c == 0 && !isNaN(f) && !isNaN(ov), nil
 	}
 
 	return false, nil
@@ -28,21 +28,15 @@ func (f Float) Compare(ctx *context.Context, other context.Valuer) (int, error)
 		return 0, err
 	}
 
-	of, ok := ov.(Float)
+	c, ok := CompareNumbers(f, ov)
 	if !ok {
 		return 0, errors.WithStack(&context.UnexpectedTypeError{
-			Wanted: []reflect.Type{reflect.TypeOf(Float(0))},
+			Wanted: []reflect.Type{reflect.TypeOf(Int(0)), reflect.TypeOf(Float(0))},
 			Got:    reflect.TypeOf(ov),
 		})
 	}
 
-	if f > of {
-		return 1, nil
-	} else if f < of {
-		return -1, nil
-	}
-
-	return 0, nil
+	return c, nil
 }
 
 type FloatFloat64Converter struct{}
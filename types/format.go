@@ -15,7 +15,7 @@ func FormatDefault(f fmt.State, c rune, v interface{}) {
 	s := "%"
 	for i := 0; i < unicode.MaxASCII; i++ {
 		if f.Flag(i) {
-			s += string(i)
+			s += string(rune(i))
 		}
 	}
 	if w, ok := f.Width(); ok {
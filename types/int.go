@@ -15,8 +15,8 @@ func (i Int) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
 		return false, err
 	}
 
-	if oi, ok := ov.(Int); ok {
-		return int64(i) == int64(oi), nil
+	if c, ok := CompareNumbers(i, ov); ok {
+		return c == 0 && !isNaN(ov), nil
 	}
 
 	return false, nil
@@ -28,21 +28,15 @@ func (i Int) Compare(ctx *context.Context, other context.Valuer) (int, error) {
 		return 0, err
 	}
 
-	oi, ok := ov.(Int)
+	c, ok := CompareNumbers(i, ov)
 	if !ok {
 		return 0, errors.WithStack(&context.UnexpectedTypeError{
-			Wanted: []reflect.Type{reflect.TypeOf(Int(0))},
+			Wanted: []reflect.Type{reflect.TypeOf(Int(0)), reflect.TypeOf(Float(0))},
 			Got:    reflect.TypeOf(ov),
 		})
 	}
 
-	if i > oi {
-		return 1, nil
-	} else if i < oi {
-		return -1, nil
-	}
-
-	return 0, nil
+	return c, nil
 }
 
 type IntInt64Converter struct{}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"encoding/json"
+	"net/netip"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+)
+
+// IP is an IPv4 or IPv6 address. Addresses are ordered by version, with IPv4
+// first, and then numerically. An IPv4-mapped address like ::ffff:10.0.0.1 is
+// equal to the IPv4 address it maps, as it is for isipv4 and CIDR matching.
+type IP struct {
+	netip.Addr
+}
+
+type ipSelector struct {
+	ip IP
+	v  context.Valuer
+}
+
+func (s *ipSelector) Value(ctx *context.Context) (interface{}, error) {
+	v, err := s.v.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sub string
+	if b, ok := v.(Bytes); ok {
+		sub = string(b)
+	} else if s, ok := v.(Str); ok {
+		sub = string(s)
+	} else {
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(Str("")),
+				reflect.TypeOf(Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	switch sub {
+	case "version":
+		if s.ip.Unmap().Is4() {
+			return Int(4), nil
+		}
+
+		return Int(6), nil
+	case "zone":
+		if z := s.ip.Zone(); z != "" {
+			return Str(z), nil
+		}
+
+		return nil, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (ip IP) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if oip, ok := ov.(IP); ok {
+		return ip.Addr.Unmap() == oip.Addr.Unmap(), nil
+	}
+
+	return false, nil
+}
+
+func (ip IP) Compare(ctx *context.Context, other context.Valuer) (int, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	oip, ok := ov.(IP)
+	if !ok {
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{reflect.TypeOf(IP{})},
+			Got:    reflect.TypeOf(ov),
+		})
+	}
+
+	return ip.Addr.Unmap().Compare(oip.Addr.Unmap()), nil
+}
+
+func (ip IP) Select(ctx *context.Context, tree []context.Valuer) (context.Valuer, error) {
+	if len(tree) != 1 {
+		return context.NewConstValuer(nil), nil
+	}
+
+	return &ipSelector{ip: ip, v: tree[0]}, nil
+}
+
+func (ip IP) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ip.String())
+}
+
+type IPConverter struct{}
+
+func (ico *IPConverter) Convert(in interface{}) interface{} {
+	return IP{in.(netip.Addr)}
+}
@@ -0,0 +1,142 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+)
+
+const (
+	// OptionDecimal is the name of the context option that enables exact
+	// decimal arithmetic on Numbers. When it is not set, Numbers used in
+	// arithmetic are first converted to an Int or a Float.
+	OptionDecimal = "decimal"
+
+	// DecimalPrecision is the number of decimal places retained when the
+	// result of a decimal division cannot be represented exactly.
+	DecimalPrecision = 34
+)
+
+// Number is an arbitrary-precision number that retains the literal it was
+// decoded from, so that it can be encoded again without any loss.
+type Number string
+
+// NewRatNumber creates a Number from a rational number. The literal is exact
+// unless the number has no finite decimal representation, in which case it is
+// rounded to DecimalPrecision places.
+func NewRatNumber(r *big.Rat) Number {
+	if r.IsInt() {
+		return Number(r.Num().String())
+	}
+
+	// A fraction in lowest terms has a finite decimal representation if and
+	// only if its denominator has no prime factors other than 2 and 5.
+	d := new(big.Int).Set(r.Denom())
+	places := 0
+	for _, p := range []int64{2, 5} {
+		bp := big.NewInt(p)
+		m := new(big.Int)
+
+		n := 0
+		for {
+			q, rem := new(big.Int).QuoRem(d, bp, m)
+			if rem.Sign() != 0 {
+				break
+			}
+
+			d = q
+			n++
+		}
+
+		if n > places {
+			places = n
+		}
+	}
+
+	if d.Cmp(big.NewInt(1)) != 0 {
+		places = DecimalPrecision
+	}
+
+	return Number(r.FloatString(places))
+}
+
+// Rat returns the exact value of the number.
+func (n Number) Rat() (*big.Rat, bool) {
+	return new(big.Rat).SetString(string(n))
+}
+
+// Demote converts the number to an Int if it is an integer that fits in 64
+// bits, and to a Float otherwise.
+func (n Number) Demote() interface{} {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return Int(i)
+	}
+
+	f, _ := strconv.ParseFloat(string(n), 64)
+	return Float(f)
+}
+
+func (n Number) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if c, ok := CompareNumbers(n, ov); ok {
+		return c == 0 && !isNaN(ov), nil
+	}
+
+	return false, nil
+}
+
+func (n Number) Compare(ctx *context.Context, other context.Valuer) (int, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	c, ok := CompareNumbers(n, ov)
+	if !ok {
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(Int(0)),
+				reflect.TypeOf(Float(0)),
+				reflect.TypeOf(Number("")),
+			},
+			Got: reflect.TypeOf(ov),
+		})
+	}
+
+	return c, nil
+}
+
+func (n Number) MarshalJSON() ([]byte, error) {
+	return []byte(n), nil
+}
+
+// NumberConverter converts decoded JSON numbers to Numbers.
+type NumberConverter struct{}
+
+func (nco *NumberConverter) Convert(in interface{}) interface{} {
+	return Number(in.(json.Number))
+}
+
+// JSONNumberConverter converts decoded JSON numbers to Ints where possible and
+// to Floats otherwise.
+type JSONNumberConverter struct{}
+
+func (jnco *JSONNumberConverter) Convert(in interface{}) interface{} {
+	return Number(in.(json.Number)).Demote()
+}
+
+// DefineNumbersIn configures a context to represent decoded JSON numbers as
+// Numbers. If decimal is true, arithmetic between Numbers is also performed
+// exactly.
+func DefineNumbersIn(ctx *context.Context, decimal bool) {
+	ctx.DefineConverter(reflect.TypeOf(json.Number("")), &NumberConverter{})
+	ctx.DefineOption(OptionDecimal, decimal)
+}
@@ -0,0 +1,192 @@
+package types
+
+import (
+	"math"
+	"math/big"
+)
+
+// PromoteNumbers converts a pair of numeric operands to a common type. If both
+// operands are integers, they are returned unchanged; if either is a float,
+// both are returned as floats. The last return value is false if either
+// operand is not a number.
+func PromoteNumbers(a, b interface{}) (interface{}, interface{}, bool) {
+	switch at := a.(type) {
+	case Int:
+		switch bt := b.(type) {
+		case Int:
+			return at, bt, true
+		case Float:
+			return Float(at), bt, true
+		}
+	case Float:
+		switch bt := b.(type) {
+		case Int:
+			return at, Float(bt), true
+		case Float:
+			return at, bt, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// ToRat returns the exact value of a numeric value. The last return value is
+// false if the value is not a number or is a non-finite float.
+func ToRat(v interface{}) (*big.Rat, bool) {
+	switch vt := v.(type) {
+	case Int:
+		return new(big.Rat).SetInt64(int64(vt)), true
+	case Float:
+		if math.IsNaN(float64(vt)) || math.IsInf(float64(vt), 0) {
+			return nil, false
+		}
+
+		return new(big.Rat).SetFloat64(float64(vt)), true
+	case Number:
+		return vt.Rat()
+	}
+
+	return nil, false
+}
+
+// CompareNumbers compares two numeric values of any numeric type without
+// losing precision for integers that cannot be represented exactly as floats.
+// NaN sorts before every other number. The last return value is false if
+// either value is not a number.
+func CompareNumbers(a, b interface{}) (int, bool) {
+	switch at := a.(type) {
+	case Int:
+		switch bt := b.(type) {
+		case Int:
+			return compareInts(at, bt), true
+		case Float:
+			return -compareFloatInt(bt, at), true
+		}
+	case Float:
+		switch bt := b.(type) {
+		case Int:
+			return compareFloatInt(at, bt), true
+		case Float:
+			return compareFloats(at, bt), true
+		}
+	}
+
+	if !isNumber(a) || !isNumber(b) {
+		return 0, false
+	}
+
+	// At least one of the values is a Number, so compare exactly. Values
+	// without an exact value are compared by the float they round to.
+	ar, aok := ToRat(a)
+	br, bok := ToRat(b)
+
+	if !aok && !bok {
+		return compareFloats(inexactFloat(a), inexactFloat(b)), true
+	} else if !aok {
+		return compareInexact(inexactFloat(a), br), true
+	} else if !bok {
+		return -compareInexact(inexactFloat(b), ar), true
+	}
+
+	return ar.Cmp(br), true
+}
+
+// inexactFloat returns the float that a number without an exact value rounds
+// to: a non-finite float, or a Number whose exponent is too large for ToRat.
+func inexactFloat(v interface{}) Float {
+	if n, ok := v.(Number); ok {
+		f, _ := n.Demote().(Float)
+		return f
+	}
+
+	f, _ := v.(Float)
+	return f
+}
+
+// compareInexact compares a number without an exact value, given as the float
+// it rounds to, with an exact value. Such a number is NaN, infinite, or so
+// close to zero that it rounds to a signed zero.
+func compareInexact(f Float, r *big.Rat) int {
+	if math.IsNaN(float64(f)) || math.IsInf(float64(f), 0) {
+		return compareFloats(f, 0)
+	}
+
+	if c := -r.Sign(); c != 0 {
+		return c
+	} else if math.Signbit(float64(f)) {
+		return -1
+	}
+
+	return 1
+}
+
+func isNumber(v interface{}) bool {
+	switch v.(type) {
+	case Int, Float, Number:
+		return true
+	}
+
+	return false
+}
+
+func isNaN(v interface{}) bool {
+	f, ok := v.(Float)
+	return ok && math.IsNaN(float64(f))
+}
+
+func compareInts(a, b Int) int {
+	if a > b {
+		return 1
+	} else if a < b {
+		return -1
+	}
+
+	return 0
+}
+
+func compareFloats(a, b Float) int {
+	an, bn := math.IsNaN(float64(a)), math.IsNaN(float64(b))
+
+	switch {
+	case an && bn:
+		return 0
+	case an:
+		return -1
+	case bn:
+		return 1
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	}
+
+	return 0
+}
+
+func compareFloatInt(f Float, i Int) int {
+	ff := float64(f)
+
+	switch {
+	case math.IsNaN(ff):
+		return -1
+	case ff >= -math.MinInt64:
+		return 1
+	case ff < math.MinInt64:
+		return -1
+	}
+
+	// The float is now within the range of an int64, so its integral part can
+	// be compared exactly.
+	t := math.Trunc(ff)
+	if c := compareInts(Int(t), i); c != 0 {
+		return c
+	}
+
+	if frac := ff - t; frac > 0 {
+		return 1
+	} else if frac < 0 {
+		return -1
+	}
+
+	return 0
+}
@@ -1,30 +1,157 @@
 package types
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/reflect/filq/context"
 )
 
-type Object map[string]interface{}
+const (
+	// OptionSortKeys is the name of the context option that causes objects to
+	// be encoded with their keys sorted instead of in insertion order.
+	OptionSortKeys = "sortkeys"
+)
+
+// Object is a collection of key-value pairs that remembers the order in which
+// its keys were first set. Objects are always used by reference.
+type Object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// NewObject creates an empty object.
+func NewObject() *Object {
+	return &Object{values: make(map[string]interface{})}
+}
+
+// NewObjectFromMap creates an object from a map. Because maps are unordered,
+// the keys of the new object are sorted.
+func NewObjectFromMap(m map[string]interface{}) *Object {
+	o := &Object{
+		keys:   make([]string, 0, len(m)),
+		values: make(map[string]interface{}, len(m)),
+	}
+
+	for key, value := range m {
+		o.keys = append(o.keys, key)
+		o.values[key] = value
+	}
+
+	sort.Strings(o.keys)
+	return o
+}
+
+// Len returns the number of keys in the object.
+func (o *Object) Len() int {
+	return len(o.keys)
+}
+
+// Keys returns the keys of the object in insertion order.
+func (o *Object) Keys() []string {
+	keys := make([]string, len(o.keys))
+	copy(keys, o.keys)
+	return keys
+}
+
+// Get returns the value for a key and whether the key is present.
+func (o *Object) Get(key string) (interface{}, bool) {
+	v, ok := o.values[key]
+	return v, ok
+}
+
+// Set sets the value for a key. A new key is added after all existing keys;
+// replacing the value of an existing key does not change its position.
+func (o *Object) Set(key string, value interface{}) {
+	if o.values == nil {
+		o.values = make(map[string]interface{})
+	}
+
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = value
+}
+
+// Delete removes a key from the object if it is present.
+func (o *Object) Delete(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Sorted returns a shallow copy of the object with its keys sorted.
+func (o *Object) Sorted() *Object {
+	so := &Object{
+		keys:   o.sortedKeys(),
+		values: make(map[string]interface{}, len(o.values)),
+	}
+
+	for key, value := range o.values {
+		so.values[key] = value
+	}
+
+	return so
+}
+
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		kb, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		vb, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(kb)
+		b.WriteByte(':')
+		b.Write(vb)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
 
-func (o Object) Format(f fmt.State, c rune) {
+func (o *Object) Format(f fmt.State, c rune) {
 	if c != 'v' || !f.Flag('+') {
-		FormatDefault(f, c, map[string]interface{}(o))
+		FormatDefault(f, c, o.values)
+		return
 	}
 
 	b, err := json.MarshalIndent(o, "", "  ")
 	if err != nil {
-		FormatDefault(f, c, map[string]interface{}(o))
+		FormatDefault(f, c, o.values)
+		return
 	}
 
 	fmt.Fprintf(f, "%s", b)
 }
 
-func (o Object) Select(ctx *context.Context, tree []context.Valuer) (context.Valuer, error) {
+func (o *Object) Select(ctx *context.Context, tree []context.Valuer) (context.Valuer, error) {
 	v, err := tree[0].Value(ctx)
 	if err != nil {
 		return nil, err
@@ -47,81 +174,105 @@ func (o Object) Select(ctx *context.Context, tree []context.Valuer) (context.Val
 		})
 	}
 
-	sel, ok := o[key]
+	sel, ok := o.values[key]
 	if !ok {
 		return context.NewConstValuer(nil), nil
 	}
 
-	sel = ctx.Convert(sel)
-
-	if len(tree) == 1 {
-		return context.NewConstValuer(sel), nil
-	} else if selectable, ok := sel.(context.Sel); ok {
-		return selectable.Select(ctx, tree[1:])
-	}
-
-	return nil, errors.WithStack(&context.UnexpectedTypeError{
-		Wanted: []reflect.Type{
-			reflect.TypeOf((*context.Sel)(nil)).Elem(),
-		},
-		Got: reflect.TypeOf(sel),
-	})
+	return selectNext(ctx, ctx.Convert(sel), tree[1:])
 }
 
-func (o Object) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
+func (o *Object) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
 	ov, err := other.Value(ctx)
 	if err != nil {
 		return false, err
 	}
 
-	if ov == nil {
+	oo, ok := ov.(*Object)
+	if !ok {
+		return false, nil
+	}
+
+	if oo.Len() != o.Len() {
 		return false, nil
 	}
 
-	oo, ok := ov.(Object)
+	for key, value := range o.values {
+		other, ok := oo.values[key]
+		if !ok {
+			return false, nil
+		}
+
+		eq, err := Equal(ctx, value, other)
+		if err != nil {
+			return false, err
+		}
+
+		if !eq {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Compare orders objects the same way as jq: first by their sorted sets of
+// keys, and then by their values in key order.
+func (o *Object) Compare(ctx *context.Context, other context.Valuer) (int, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	oo, ok := ov.(*Object)
 	if !ok {
-		return false, errors.WithStack(&context.UnexpectedTypeError{
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
 			Wanted: []reflect.Type{
-				reflect.TypeOf(Object(map[string]interface{}{})),
+				reflect.TypeOf(&Object{}),
 			},
 			Got: reflect.TypeOf(ov),
 		})
 	}
 
-	if len(oo) != len(o) {
-		return false, nil
+	keys, okeys := o.sortedKeys(), oo.sortedKeys()
+	for i := 0; i < len(keys) && i < len(okeys); i++ {
+		if c := strings.Compare(keys[i], okeys[i]); c != 0 {
+			return c, nil
+		}
 	}
 
-	for key, value := range o {
-		other, ok := oo[key]
-		if !ok {
-			return false, nil
-		}
+	if c := compareInts(Int(len(keys)), Int(len(okeys))); c != 0 {
+		return c, nil
+	}
 
-		if ve, ok := value.(context.Eq); ok {
-			eq, err := ve.Equal(ctx, context.NewConstValuer(other))
-			if err != nil {
-				return false, err
-			}
+	for _, key := range keys {
+		c, err := Compare(ctx, o.values[key], oo.values[key])
+		if err != nil {
+			return 0, err
+		}
 
-			if !eq {
-				return false, nil
-			}
-		} else if value != other {
-			return false, nil
+		if c != 0 {
+			return c, nil
 		}
 	}
 
-	return true, nil
+	return 0, nil
 }
 
-func (o Object) Expand(ctx *context.Context) ([]context.Valuer, error) {
-	out := make([]context.Valuer, len(o))
+func (o *Object) sortedKeys() []string {
+	keys := o.Keys()
+	sort.Strings(keys)
+	return keys
+}
 
-	i := 0
-	for key, value := range o {
-		out[i] = NewEntryValuer(Str(key), ctx.Convert(value))
-		i++
+func (o *Object) Expand(ctx *context.Context) ([]context.Valuer, error) {
+	out := make([]context.Valuer, len(o.keys))
+	for i, key := range o.keys {
+		if ctx.Dialect() == context.DialectJQ {
+			out[i] = context.NewConstValuer(ctx.Convert(o.values[key]))
+		} else {
+			out[i] = NewEntryValuer(Str(key), ctx.Convert(o.values[key]))
+		}
 	}
 
 	return out, nil
@@ -130,5 +281,32 @@ func (o Object) Expand(ctx *context.Context) ([]context.Valuer, error) {
 type ObjectConverter struct{}
 
 func (oco *ObjectConverter) Convert(in interface{}) interface{} {
-	return Object(in.(map[string]interface{}))
+	return NewObjectFromMap(in.(map[string]interface{}))
+}
+
+// SortKeys returns a copy of a value in which the keys of every object,
+// including nested objects, are sorted.
+func SortKeys(v interface{}) interface{} {
+	switch vt := v.(type) {
+	case *Object:
+		so := vt.Sorted()
+		for key, value := range so.values {
+			so.values[key] = SortKeys(value)
+		}
+
+		return so
+	case map[string]interface{}:
+		return SortKeys(NewObjectFromMap(vt))
+	case Array:
+		return Array(SortKeys([]interface{}(vt)).([]interface{}))
+	case []interface{}:
+		out := make([]interface{}, len(vt))
+		for i, value := range vt {
+			out[i] = SortKeys(value)
+		}
+
+		return out
+	default:
+		return v
+	}
 }
@@ -0,0 +1,53 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/reflect/filq/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectOrder(t *testing.T) {
+	o := NewObject()
+	o.Set("z", 1)
+	o.Set("a", 2)
+	o.Set("m", 3)
+	o.Set("z", 4)
+
+	assert.Equal(t, []string{"z", "a", "m"}, o.Keys())
+
+	b, err := json.Marshal(o)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"z":4,"a":2,"m":3}`, string(b))
+
+	o.Delete("a")
+	o.Delete("missing")
+	assert.Equal(t, []string{"z", "m"}, o.Keys())
+
+	ctx := context.OverlayContext(nil)
+	vrs, err := o.Expand(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []context.Valuer{
+		NewEntryValuer(Str("z"), 4),
+		NewEntryValuer(Str("m"), 3),
+	}, vrs)
+}
+
+func TestSortKeys(t *testing.T) {
+	inner := NewObject()
+	inner.Set("y", 1)
+	inner.Set("b", 2)
+
+	o := NewObject()
+	o.Set("z", Array{inner})
+	o.Set("a", map[string]interface{}{"d": 1, "c": 2})
+
+	b, err := json.Marshal(SortKeys(o))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{"c":2,"d":1},"z":[{"b":2,"y":1}]}`, string(b))
+
+	// The original object is unchanged.
+	assert.Equal(t, []string{"z", "a"}, o.Keys())
+	assert.Equal(t, []string{"y", "b"}, inner.Keys())
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+)
+
+// selectNext continues a selection into a value that was itself selected from
+// a container.
+func selectNext(ctx *context.Context, sel interface{}, tree []context.Valuer) (context.Valuer, error) {
+	if len(tree) == 0 {
+		return context.NewConstValuer(sel), nil
+	} else if selectable, ok := sel.(context.Sel); ok {
+		return selectable.Select(ctx, tree)
+	} else if sel == nil && ctx.Dialect() == context.DialectJQ {
+		return context.NewConstValuer(nil), nil
+	}
+
+	return nil, errors.WithStack(&context.UnexpectedTypeError{
+		Wanted: []reflect.Type{
+			reflect.TypeOf((*context.Sel)(nil)).Elem(),
+		},
+		Got: reflect.TypeOf(sel),
+	})
+}
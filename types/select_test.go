@@ -0,0 +1,90 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reflect/filq/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArraySelectDialect(t *testing.T) {
+	a := Array{int64(0), int64(1), int64(2), int64(3)}
+
+	conds := []struct {
+		Dialect  context.Dialect
+		Sel      interface{}
+		Expected interface{}
+	}{
+		{context.DialectFilq, Int(-1), nil},
+		{context.DialectJQ, Int(-1), Int(3)},
+		{context.DialectFilq, Slice{Left: 1, Right: 2}, Array{int64(1), int64(2)}},
+		{context.DialectJQ, Slice{Left: 1, Right: 2}, Array{int64(1)}},
+		{context.DialectFilq, Slice{Left: -2, Right: 10}, Array{int64(0), int64(1), int64(2), int64(3)}},
+		{context.DialectJQ, Slice{Left: -2, Right: 10}, Array{int64(2), int64(3)}},
+		{context.DialectJQ, Slice{Left: 1, Right: -1}, Array{int64(1), int64(2)}},
+	}
+
+	for _, cond := range conds {
+		ctx := context.OverlayContext(nil)
+		DefineIn(ctx)
+		ctx.SetDialect(cond.Dialect)
+
+		vr, err := a.Select(ctx, []context.Valuer{context.NewConstValuer(cond.Sel)})
+		assert.NoError(t, err)
+
+		v, err := vr.Value(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, v, "%v", cond.Sel)
+	}
+}
+
+func TestObjectDialect(t *testing.T) {
+	o := NewObject()
+	o.Set("a", nil)
+	o.Set("b", int64(1))
+
+	ctx := context.OverlayContext(nil)
+	DefineIn(ctx)
+
+	_, err := o.Select(ctx, []context.Valuer{context.NewConstValuer("a"), context.NewConstValuer("c")})
+	assert.Error(t, err)
+
+	vrs, err := o.Expand(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []context.Valuer{NewEntryValuer(Str("a"), nil), NewEntryValuer(Str("b"), Int(1))}, vrs)
+
+	ctx.SetDialect(context.DialectJQ)
+
+	vr, err := o.Select(ctx, []context.Valuer{context.NewConstValuer("a"), context.NewConstValuer("c")})
+	assert.NoError(t, err)
+	assert.Equal(t, context.NewConstValuer(nil), vr)
+
+	vrs, err = o.Expand(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []context.Valuer{context.NewConstValuer(nil), context.NewConstValuer(Int(1))}, vrs)
+}
+
+func TestTimeSelectUnixMillis(t *testing.T) {
+	ctx := context.OverlayContext(nil)
+	DefineIn(ctx)
+
+	// Times outside 1678 to 2262 do not fit in an int64 of nanoseconds.
+	conds := []struct {
+		Time     time.Time
+		Expected Int
+	}{
+		{time.Date(2015, time.March, 5, 23, 51, 47, 500000000, time.UTC), 1425599507500},
+		{time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC), -30610224000000},
+		{time.Date(3000, time.January, 1, 0, 0, 0, 0, time.UTC), 32503680000000},
+	}
+
+	for _, cond := range conds {
+		sel, err := Time{Time: cond.Time}.Select(ctx, []context.Valuer{context.NewConstValuer("unix_ms")})
+		assert.NoError(t, err)
+
+		v, err := sel.Value(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, cond.Expected, v, cond.Time.String())
+	}
+}
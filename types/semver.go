@@ -0,0 +1,199 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+)
+
+// Semver is a semantic version as described by https://semver.org/. Versions
+// are ordered by precedence, so build metadata is ignored when comparing
+// them.
+type Semver struct {
+	Major, Minor, Patch uint64
+	Prerelease          []string
+	Build               []string
+}
+
+func (v Semver) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		s += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) > 0 {
+		s += "+" + strings.Join(v.Build, ".")
+	}
+
+	return s
+}
+
+type semverSelector struct {
+	v  Semver
+	sv context.Valuer
+}
+
+func (s *semverSelector) Value(ctx *context.Context) (interface{}, error) {
+	v, err := s.sv.Value(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sub string
+	if b, ok := v.(Bytes); ok {
+		sub = string(b)
+	} else if s, ok := v.(Str); ok {
+		sub = string(s)
+	} else {
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(Str("")),
+				reflect.TypeOf(Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+
+	switch sub {
+	case "major":
+		return Int(s.v.Major), nil
+	case "minor":
+		return Int(s.v.Minor), nil
+	case "patch":
+		return Int(s.v.Patch), nil
+	case "prerelease":
+		if len(s.v.Prerelease) == 0 {
+			return nil, nil
+		}
+
+		return Str(strings.Join(s.v.Prerelease, ".")), nil
+	case "build":
+		if len(s.v.Build) == 0 {
+			return nil, nil
+		}
+
+		return Str(strings.Join(s.v.Build, ".")), nil
+	default:
+		return nil, nil
+	}
+}
+
+// comparePrerelease orders pre-release identifiers. Numeric identifiers sort
+// before alphanumeric ones and are compared numerically; a version without a
+// pre-release sorts after any version with one.
+func comparePrerelease(a, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		an, bn := isNumericIdentifier(a[i]), isNumericIdentifier(b[i])
+
+		var c int
+		switch {
+		case an && bn:
+			c = compareInts(Int(len(a[i])), Int(len(b[i])))
+			if c == 0 {
+				c = strings.Compare(a[i], b[i])
+			}
+		case an:
+			c = -1
+		case bn:
+			c = 1
+		default:
+			c = strings.Compare(a[i], b[i])
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return compareInts(Int(len(a)), Int(len(b)))
+}
+
+func isNumericIdentifier(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ComparePrecedence orders two versions by precedence.
+func (v Semver) ComparePrecedence(o Semver) int {
+	if c := compareUints(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUints(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUints(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+func (v Semver) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if osv, ok := ov.(Semver); ok {
+		return v.ComparePrecedence(osv) == 0, nil
+	}
+
+	return false, nil
+}
+
+func (v Semver) Compare(ctx *context.Context, other context.Valuer) (int, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	osv, ok := ov.(Semver)
+	if !ok {
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{reflect.TypeOf(Semver{})},
+			Got:    reflect.TypeOf(ov),
+		})
+	}
+
+	return v.ComparePrecedence(osv), nil
+}
+
+func (v Semver) Select(ctx *context.Context, tree []context.Valuer) (context.Valuer, error) {
+	if len(tree) != 1 {
+		return context.NewConstValuer(nil), nil
+	}
+
+	return &semverSelector{v: v, sv: tree[0]}, nil
+}
+
+func (v Semver) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
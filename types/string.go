@@ -26,6 +26,28 @@ func (s Str) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
 	return false, nil
 }
 
+func (s Str) Compare(ctx *context.Context, other context.Valuer) (int, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	switch ot := ov.(type) {
+	case Str:
+		return strings.Compare(string(s), string(ot)), nil
+	case Bytes:
+		return bytes.Compare([]byte(s), ot), nil
+	default:
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(Str("")),
+				reflect.TypeOf(Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(ov),
+		})
+	}
+}
+
 func (s Str) Index(ctx *context.Context, key context.Valuer) (context.Valuer, error) {
 	k, err := key.Value(ctx)
 	if err != nil {
@@ -40,7 +62,7 @@ func (s Str) Index(ctx *context.Context, key context.Valuer) (context.Valuer, er
 	case Bytes:
 		r = strings.Index(string(s), string(kt))
 	case Int:
-		r = strings.Index(string(s), string(kt))
+		r = strings.IndexRune(string(s), rune(kt))
 	case byte:
 		r = strings.IndexByte(string(s), kt)
 	case rune:
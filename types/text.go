@@ -0,0 +1,97 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/reflect/filq/context"
+)
+
+// StringOf returns the contents of a string or bytes value.
+func StringOf(v interface{}) (string, error) {
+	switch vt := v.(type) {
+	case Str:
+		return string(vt), nil
+	case Bytes:
+		return string(vt), nil
+	default:
+		return "", errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(Str("")),
+				reflect.TypeOf(Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+}
+
+// BytesOf returns the contents of a string or bytes value.
+func BytesOf(v interface{}) ([]byte, error) {
+	switch vt := v.(type) {
+	case Str:
+		return []byte(vt), nil
+	case Bytes:
+		return vt, nil
+	default:
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf(Str("")),
+				reflect.TypeOf(Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+}
+
+// ReaderOf returns a reader over a string or bytes value. Readers are
+// returned as they are, so that streams can be decoded incrementally.
+func ReaderOf(v interface{}) (io.Reader, error) {
+	if r, ok := v.(io.Reader); ok {
+		return r, nil
+	}
+
+	switch vt := v.(type) {
+	case Str:
+		return bytes.NewReader([]byte(vt)), nil
+	case Bytes:
+		return bytes.NewReader(vt), nil
+	default:
+		return nil, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{
+				reflect.TypeOf((*io.Reader)(nil)).Elem(),
+				reflect.TypeOf(Str("")),
+				reflect.TypeOf(Bytes([]byte{})),
+			},
+			Got: reflect.TypeOf(v),
+		})
+	}
+}
+
+// TextOf formats a scalar as text. Strings are used as is, null is empty and
+// anything else is formatted as JSON, without the quotes around values that
+// marshal as JSON strings.
+func TextOf(v interface{}) (string, error) {
+	switch vt := v.(type) {
+	case nil:
+		return "", nil
+	case Str:
+		return string(vt), nil
+	case Bytes:
+		return string(vt), nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.Wrap(err, "marshaling text")
+	}
+
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		return s, nil
+	}
+
+	return string(b), nil
+}
@@ -38,6 +38,9 @@ func (s *timeSelector) Value(ctx *context.Context) (interface{}, error) {
 		})
 	}
 
+	// Months and days of the year count from 1, as on a calendar. This
+	// differs from jq's broken down times (gmtime and mktime), which count
+	// both from 0.
 	switch sub {
 	case "year":
 		return ctx.Convert(s.t.Year()), nil
@@ -53,6 +56,22 @@ func (s *timeSelector) Value(ctx *context.Context) (interface{}, error) {
 		return ctx.Convert(s.t.Second()), nil
 	case "nanosecond":
 		return ctx.Convert(s.t.Nanosecond()), nil
+	case "weekday":
+		return ctx.Convert(int(s.t.Weekday())), nil
+	case "isoweek":
+		_, week := s.t.ISOWeek()
+		return ctx.Convert(week), nil
+	case "yday":
+		return ctx.Convert(s.t.YearDay()), nil
+	case "zone":
+		return ctx.Convert(s.t.Location().String()), nil
+	case "offset":
+		_, offset := s.t.Zone()
+		return ctx.Convert(offset), nil
+	case "unix":
+		return ctx.Convert(s.t.Unix()), nil
+	case "unix_ms":
+		return ctx.Convert(s.t.UnixMilli()), nil
 	default:
 		return nil, nil
 	}
@@ -65,12 +84,35 @@ func (t Time) Equal(ctx *context.Context, other context.Valuer) (bool, error) {
 	}
 
 	if ot, ok := ov.(Time); ok {
-		return t.Time == ot.Time, nil
+		return t.Time.Equal(ot.Time), nil
 	}
 
 	return false, nil
 }
 
+func (t Time) Compare(ctx *context.Context, other context.Valuer) (int, error) {
+	ov, err := other.Value(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ot, ok := ov.(Time)
+	if !ok {
+		return 0, errors.WithStack(&context.UnexpectedTypeError{
+			Wanted: []reflect.Type{reflect.TypeOf(Time{})},
+			Got:    reflect.TypeOf(ov),
+		})
+	}
+
+	if t.After(ot.Time) {
+		return 1, nil
+	} else if t.Before(ot.Time) {
+		return -1, nil
+	}
+
+	return 0, nil
+}
+
 func (t Time) Select(ctx *context.Context, tree []context.Valuer) (context.Valuer, error) {
 	if len(tree) != 1 {
 		return context.NewConstValuer(nil), nil
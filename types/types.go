@@ -1,6 +1,8 @@
 package types
 
 import (
+	"encoding/json"
+	"net/netip"
 	"reflect"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 func DefineIn(ctx *context.Context) {
 	ctx.DefineConverter(reflect.TypeOf([]interface{}{}), &ArrayConverter{})
 	ctx.DefineConverter(reflect.TypeOf([]byte{}), &BytesConverter{})
+	ctx.DefineConverter(reflect.TypeOf(time.Duration(0)), &DurationConverter{})
 	ctx.DefineConverter(reflect.TypeOf(float64(0)), &FloatFloat64Converter{})
 	ctx.DefineConverter(reflect.TypeOf(float32(0)), &FloatFloat32Converter{})
 	ctx.DefineConverter(reflect.TypeOf(int64(0)), &IntInt64Converter{})
@@ -17,6 +20,8 @@ func DefineIn(ctx *context.Context) {
 	ctx.DefineConverter(reflect.TypeOf(int16(0)), &IntInt16Converter{})
 	ctx.DefineConverter(reflect.TypeOf(int8(0)), &IntInt8Converter{})
 	ctx.DefineConverter(reflect.TypeOf(int(0)), &IntIntConverter{})
+	ctx.DefineConverter(reflect.TypeOf(json.Number("")), &JSONNumberConverter{})
+	ctx.DefineConverter(reflect.TypeOf(netip.Addr{}), &IPConverter{})
 	ctx.DefineConverter(reflect.TypeOf(map[string]interface{}{}), &ObjectConverter{})
 	ctx.DefineConverter(reflect.TypeOf(""), &StrConverter{})
 	ctx.DefineConverter(reflect.TypeOf(time.Time{}), &TimeConverter{})